@@ -32,6 +32,11 @@ func Analyzer(input string) (interface{}, error) {
 			return nil, errors.New("no se proporcionó ningún comando")
 		}
 
+		// Verificar que el usuario en sesión pueda ejecutar el comando
+		if err := commands.Authorize(tokens[0]); err != nil {
+			return nil, err
+		}
+
 		// Switch para manejar diferentes comandos
 		var result interface{}
 		var err error
@@ -56,7 +61,7 @@ func Analyzer(input string) (interface{}, error) {
 		case "mkusr":
 			result, err = commands.ParserMkuser(tokens[1:])
 		case "logout":
-			commands.IsLogged = false
+			commands.Logout()
 			result = "Sesión cerrada"
 		case "rmgrp":
 			result, err = commands.ParserRmgrp(tokens[1:])
@@ -64,10 +69,24 @@ func Analyzer(input string) (interface{}, error) {
 			result, err = commands.ParserRmusr(tokens[1:])
 		case "chgrp":
 			result, err = commands.ParserChgrp(tokens[1:])
+		case "passwd":
+			result, err = commands.ParserPasswd(tokens[1:])
+		case "chown":
+			result, err = commands.ParserChown(tokens[1:])
 		case "mkdir":
 			result, err = commands.ParserMkdir(tokens[1:])
 		case "mkfile":
 			result, err = commands.ParserMkfile(tokens[1:])
+		case "edit":
+			result, err = commands.ParserEdit(tokens[1:])
+		case "rename":
+			result, err = commands.ParserRename(tokens[1:])
+		case "copy":
+			result, err = commands.ParserCopy(tokens[1:])
+		case "move":
+			result, err = commands.ParserMove(tokens[1:])
+		case "find":
+			result, err = commands.ParserFind(tokens[1:])
 		case "clear":
 			cmd := exec.Command("clear")
 			cmd.Stdout = os.Stdout
@@ -0,0 +1,62 @@
+package Commands
+
+import (
+	structures "archivos_pro1/Structures"
+	"errors"
+	"fmt"
+)
+
+// rootUser es el usuario administrador creado por mkfs
+const rootUser = "root"
+
+// rootGroup es el grupo del usuario administrador creado por mkfs
+const rootGroup = "root"
+
+// rootOnlyCommands son los comandos administrativos que solo puede ejecutar root
+var rootOnlyCommands = map[string]bool{
+	"mkgrp": true,
+	"mkusr": true,
+	"rmgrp": true,
+	"rmusr": true,
+	"chgrp": true,
+}
+
+// isRoot indica si el usuario en sesión es root
+func isRoot() bool {
+	return IsLogged && UserLogged == rootUser
+}
+
+// Authorize verifica que el usuario en sesión pueda ejecutar el comando indicado
+func Authorize(command string) error {
+	if !rootOnlyCommands[command] {
+		return nil
+	}
+
+	if !IsLogged {
+		return errors.New("you must be logged to execute this command")
+	}
+
+	if !isRoot() {
+		return fmt.Errorf("permission denied: only root can execute %s", command)
+	}
+
+	return nil
+}
+
+// checkUsersFileWrite impide que un usuario distinto de root modifique, renombre o mueva /users.txt,
+// ya que de su contenido dependen el inicio de sesión y los comandos administrativos
+func checkUsersFileWrite(sb *structures.SuperBlock, path string, inodeIndex int32) error {
+	if isRoot() {
+		return nil
+	}
+
+	usersIndex, _, err := sb.FindInode(path, "/users.txt")
+	if err != nil {
+		return err
+	}
+	if inodeIndex == usersIndex {
+		return errors.New("solo root puede modificar /users.txt")
+	}
+
+	return nil
+}
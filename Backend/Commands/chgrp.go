@@ -82,42 +82,19 @@ func commandChgrp(chgrp *CHGRP) error {
 		return errorD
 	}
 
-	var fullString string
-	fullString, err = sb.PrintUsersFileContent(path)
+	//Actualizar el grupo del usuario en users.txt
+	err = sb.UpdateUsersFile(path, func(content string) (string, error) {
+		return structures.SetUserGroup(content, chgrp.Usuario, chgrp.Grp)
+	})
 	if err != nil {
 		return err
 	}
 
-	//Verificar si el grupo existe
-	if _, exists := global.GroupsUser[IdPartitionGlobal][chgrp.Grp]; !exists {
-		return errors.New("el grupo no existe")
-	}
-
-	foundUser := false
-
-	for _, value := range global.GroupsUser[IdPartitionGlobal] {
-		for _, user := range value {
-			if user == chgrp.Usuario {
-				foundUser = true
-				break
-			}
-		}
-	}
-
-	if !foundUser {
-		return errors.New("el usuario no existe")
-	}
-
-	//Actualizar el grupo del usuario
-	err = sb.UpdateGroupForUser(path, chgrp.Usuario, chgrp.Grp, fullString)
-
+	err = sb.Serialize(path, int64(mountedPartition.Part_start))
 	if err != nil {
-		fmt.Println("Error:", err)
 		return err
 	}
 
-	//Actualizar el map global
-	global.GroupsUser[IdPartitionGlobal][chgrp.Grp] = append(global.GroupsUser[IdPartitionGlobal][chgrp.Grp], chgrp.Usuario)
 	fmt.Println("Usuario agregado al grupo: ", chgrp.Grp, "Usuario: ", chgrp.Usuario)
 	return nil
 
@@ -0,0 +1,164 @@
+package Commands
+
+import (
+	structures "archivos_pro1/Structures"
+	"archivos_pro1/global"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type CHOWN struct {
+	Path    string
+	Usuario string
+	R       bool
+}
+
+/*
+   chown -path=/home -r -usuario=user1
+   chown -path="/home/mis documentos/a.txt" -usuario=user2
+*/
+
+func ParserChown(tokens []string) (string, error) {
+	cmd := &CHOWN{}
+
+	args := strings.Join(tokens, " ")
+
+	re := regexp.MustCompile(`-path="[^"]+"|-path=[^\s]+|-usuario="[^"]+"|-usuario=[^\s]+|-r`)
+	matches := re.FindAllString(args, -1)
+
+	for _, match := range matches {
+		kv := strings.SplitN(match, "=", 2)
+		key := strings.ToLower(kv[0])
+		var value string
+		if len(kv) == 2 {
+			value = kv[1]
+		}
+
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		switch key {
+		case "-path":
+			if value == "" {
+				return "", errors.New("the path cannot be empty")
+			}
+			cmd.Path = value
+		case "-usuario":
+			if value == "" {
+				return "", errors.New("the user cannot be empty")
+			}
+			cmd.Usuario = value
+		case "-r":
+			cmd.R = true
+		default:
+			return "", fmt.Errorf("unknown parameter: %s", key)
+		}
+	}
+
+	if cmd.Path == "" {
+		return "", errors.New("there is a missing required parameter: -path")
+	}
+
+	if cmd.Usuario == "" {
+		return "", errors.New("there is a missing required parameter: -usuario")
+	}
+
+	skipped, err := commandChown(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	result := "CHOWN: Owner of " + cmd.Path + " changed to " + cmd.Usuario + " successfully"
+	if len(skipped) > 0 {
+		result += "\nSkipped (no permission): " + strings.Join(skipped, ", ")
+	}
+	return result, nil
+}
+
+func commandChown(chown *CHOWN) ([]string, error) {
+	if !IsLogged {
+		return nil, errors.New("you must be logged to execute this command")
+	}
+
+	mountedPartition, path, err := global.GetMountedPartition(IdPartitionGlobal)
+	if err != nil {
+		return nil, err
+	}
+
+	sb := &structures.SuperBlock{}
+	err = sb.Deserialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return nil, err
+	}
+
+	// Obtener el UID y GID del nuevo propietario
+	uid, gid, err := sb.GetUserIDs(path, chown.Usuario)
+	if err != nil {
+		return nil, err
+	}
+
+	// Buscar el inodo de la ruta
+	inodeIndex, inode, err := sb.FindInode(path, chown.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solo root o el propietario actual pueden cambiar el propietario
+	if !canChangeOwner(inode) {
+		return nil, fmt.Errorf("no tienes permiso para cambiar el propietario de %s", chown.Path)
+	}
+
+	return changeOwner(sb, path, chown.Path, inodeIndex, inode, uid, gid, chown.R)
+}
+
+// canChangeOwner indica si el usuario con sesión activa puede cambiar el propietario del inodo
+func canChangeOwner(inode *structures.Inode) bool {
+	return isRoot() || inode.I_uid == UidLogged
+}
+
+// changeOwner asigna el nuevo propietario al inodo y, si es recursivo, a todo su contenido
+// Los inodos hijos que el usuario no puede modificar se dejan sin cambios y se devuelven sus rutas
+func changeOwner(sb *structures.SuperBlock, path string, inodePath string, inodeIndex int32, inode *structures.Inode, uid int32, gid int32, recursive bool) ([]string, error) {
+	inode.I_uid = uid
+	inode.I_gid = gid
+	err := inode.Serialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return nil, err
+	}
+
+	if !recursive || inode.I_type[0] != '0' {
+		return nil, nil
+	}
+
+	entries, err := sb.ListFolder(path, inode)
+	if err != nil {
+		return nil, err
+	}
+
+	var skipped []string
+	for _, entry := range entries {
+		childPath := strings.TrimSuffix(inodePath, "/") + "/" + entry.Name
+
+		child := &structures.Inode{}
+		err := child.Deserialize(path, sb.InodeOffset(entry.Inode))
+		if err != nil {
+			return nil, err
+		}
+
+		if !canChangeOwner(child) {
+			skipped = append(skipped, childPath)
+			continue
+		}
+
+		childSkipped, err := changeOwner(sb, path, childPath, entry.Inode, child, uid, gid, recursive)
+		if err != nil {
+			return nil, err
+		}
+		skipped = append(skipped, childSkipped...)
+	}
+
+	return skipped, nil
+}
@@ -0,0 +1,146 @@
+package Commands
+
+import (
+	structures "archivos_pro1/Structures"
+	"archivos_pro1/global"
+	utils "archivos_pro1/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type COPY struct {
+	Path    string // Ruta del archivo o carpeta a copiar
+	Destino string // Carpeta donde se colocará la copia
+}
+
+/*
+   copy -path=/home/user/docs -destino=/home/images
+   copy -path="/home/mis documentos/a.txt" -destino=/home
+*/
+
+func ParserCopy(tokens []string) (string, error) {
+	cmd := &COPY{}
+
+	args := strings.Join(tokens, " ")
+
+	re := regexp.MustCompile(`-path="[^"]+"|-path=[^\s]+|-destino="[^"]+"|-destino=[^\s]+`)
+	matches := re.FindAllString(args, -1)
+
+	for _, match := range matches {
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return "", fmt.Errorf("format of parameter is invalid: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		switch key {
+		case "-path":
+			if value == "" {
+				return "", errors.New("the path cannot be empty")
+			}
+			cmd.Path = value
+		case "-destino":
+			if value == "" {
+				return "", errors.New("the destination cannot be empty")
+			}
+			cmd.Destino = value
+		default:
+			return "", fmt.Errorf("unknown parameter: %s", key)
+		}
+	}
+
+	if cmd.Path == "" {
+		return "", errors.New("there is a missing required parameter: -path")
+	}
+
+	if cmd.Destino == "" {
+		return "", errors.New("there is a missing required parameter: -destino")
+	}
+
+	skipped, err := commandCopy(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	result := "COPY: " + cmd.Path + " copied to " + cmd.Destino + " successfully"
+	if len(skipped) > 0 {
+		result += "\nSkipped (no read permission): " + strings.Join(skipped, ", ")
+	}
+	return result, nil
+}
+
+func commandCopy(cp *COPY) ([]string, error) {
+	if !IsLogged {
+		return nil, errors.New("you must be logged to execute this command")
+	}
+
+	mountedPartition, path, err := global.GetMountedPartition(IdPartitionGlobal)
+	if err != nil {
+		return nil, err
+	}
+
+	sb := &structures.SuperBlock{}
+	err = sb.Deserialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return nil, err
+	}
+
+	// Buscar el origen
+	_, name := utils.GetParentDirectories(cp.Path)
+	if name == "" {
+		return nil, errors.New("no se puede copiar la carpeta raíz")
+	}
+	_, src, err := sb.FindInode(path, cp.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !src.HasPermission(UidLogged, GidLogged, 4) {
+		return nil, fmt.Errorf("no tienes permiso de lectura sobre %s", cp.Path)
+	}
+
+	// Una carpeta no puede copiarse dentro de sí misma
+	if src.I_type[0] == '0' && utils.IsSubPath(cp.Path, cp.Destino) {
+		return nil, errors.New("no se puede copiar una carpeta dentro de sí misma")
+	}
+
+	// Buscar la carpeta destino
+	destIndex, dest, err := sb.FindInode(path, cp.Destino)
+	if err != nil {
+		return nil, err
+	}
+	if dest.I_type[0] != '0' {
+		return nil, fmt.Errorf("%s no es una carpeta", cp.Destino)
+	}
+	if !dest.HasPermission(UidLogged, GidLogged, 2) {
+		return nil, fmt.Errorf("no tienes permiso de escritura sobre %s", cp.Destino)
+	}
+
+	existing, err := sb.FindEntry(path, dest, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("ya existe %s en %s", name, cp.Destino)
+	}
+
+	// Copiar el árbol completo
+	skipped, copyErr := sb.CopyInode(path, src, cp.Path, destIndex, dest, name, UidLogged, GidLogged)
+
+	// Serializar el superbloque aunque la copia falle a medias, para que coincida con los bitmaps
+	err = sb.Serialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return nil, err
+	}
+	if copyErr != nil {
+		return nil, copyErr
+	}
+
+	return skipped, nil
+}
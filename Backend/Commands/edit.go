@@ -0,0 +1,125 @@
+package Commands
+
+import (
+	structures "archivos_pro1/Structures"
+	"archivos_pro1/global"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+type EDIT struct {
+	Path      string // Ruta del archivo dentro de la partición
+	Contenido string // Ruta del archivo en el sistema anfitrión con el nuevo contenido
+}
+
+/*
+   edit -path=/home/user/docs/a.txt -contenido=/home/jose/nuevo.txt
+   edit -path="/home/mis documentos/a.txt" -contenido="/home/jose/mis archivos/b.txt"
+*/
+
+func ParserEdit(tokens []string) (string, error) {
+	cmd := &EDIT{}
+
+	args := strings.Join(tokens, " ")
+
+	re := regexp.MustCompile(`-path="[^"]+"|-path=[^\s]+|-contenido="[^"]+"|-contenido=[^\s]+`)
+	matches := re.FindAllString(args, -1)
+
+	for _, match := range matches {
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return "", fmt.Errorf("format of parameter is invalid: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		switch key {
+		case "-path":
+			if value == "" {
+				return "", errors.New("the path cannot be empty")
+			}
+			cmd.Path = value
+		case "-contenido":
+			if value == "" {
+				return "", errors.New("the content path cannot be empty")
+			}
+			cmd.Contenido = value
+		default:
+			return "", fmt.Errorf("unknown parameter: %s", key)
+		}
+	}
+
+	if cmd.Path == "" {
+		return "", errors.New("there is a missing required parameter: -path")
+	}
+
+	if cmd.Contenido == "" {
+		return "", errors.New("there is a missing required parameter: -contenido")
+	}
+
+	err := commandEdit(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return "EDIT: File " + cmd.Path + " edited successfully", nil
+}
+
+func commandEdit(edit *EDIT) error {
+	if !IsLogged {
+		return errors.New("you must be logged to execute this command")
+	}
+
+	// Leer el nuevo contenido desde el sistema anfitrión
+	content, err := os.ReadFile(edit.Contenido)
+	if err != nil {
+		return fmt.Errorf("error al leer el archivo %s: %w", edit.Contenido, err)
+	}
+
+	mountedPartition, path, err := global.GetMountedPartition(IdPartitionGlobal)
+	if err != nil {
+		return err
+	}
+
+	sb := &structures.SuperBlock{}
+	err = sb.Deserialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return err
+	}
+
+	// Buscar el inodo del archivo
+	inodeIndex, inode, err := sb.FindInode(path, edit.Path)
+	if err != nil {
+		return err
+	}
+
+	if inode.I_type[0] != '1' {
+		return fmt.Errorf("%s no es un archivo", edit.Path)
+	}
+
+	// Verificar el permiso de escritura
+	if !inode.HasPermission(UidLogged, GidLogged, 2) {
+		return fmt.Errorf("no tienes permiso de escritura sobre %s", edit.Path)
+	}
+	err = checkUsersFileWrite(sb, path, inodeIndex)
+	if err != nil {
+		return err
+	}
+
+	// Reescribir los bloques del archivo
+	writeErr := sb.WriteFileContent(path, inodeIndex, inode, string(content))
+
+	// Serializar el superbloque aunque la escritura falle a medias, para que coincida con los bitmaps
+	err = sb.Serialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return err
+	}
+
+	return writeErr
+}
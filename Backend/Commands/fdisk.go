@@ -3,11 +3,8 @@ package Commands
 import (
 	structures "archivos_pro1/Structures"
 	utils "archivos_pro1/utils"
-	"encoding/binary"
-	"errors" // Paquete para manejar errores y crear nuevos errores con mensajes personalizados
-	"fmt"    // Paquete para formatear cadenas y realizar operaciones de entrada/salida
-	"math/rand"
-	"os"
+	"errors"  // Paquete para manejar errores y crear nuevos errores con mensajes personalizados
+	"fmt"     // Paquete para formatear cadenas y realizar operaciones de entrada/salida
 	"regexp"  // Paquete para trabajar con expresiones regulares, útil para encontrar y manipular patrones en cadenas
 	"strconv" // Paquete para convertir cadenas a otros tipos de datos, como enteros
 	"strings" // Paquete para manipular cadenas, como unir, dividir, y modificar contenido de cadenas
@@ -133,12 +130,6 @@ func ParserFdisk(tokens []string) (string, error) {
 		fmt.Println("Error:", err)
 	}
 
-	//generar reporte
-	err = GenerateFdiskReport(cmd.path)
-	if err != nil {
-		fmt.Println("Error generando reporte:", err)
-	}
-
 	return "FDISK: Partition created successfully", nil
 }
 
@@ -249,59 +240,16 @@ func createExtendedPartition(fdisk *FDISK, sizeBytes int) error {
 	return nil
 }
 
-// Serializar EBR
-func serializeEBR(path string, ebr *structures.EBR) error {
-	file, err := os.OpenFile(path, os.O_WRONLY, 0644)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	_, err = file.Seek(int64(ebr.Part_start-30), 0)
-	if err != nil {
-		return err
-	}
-
-	err = binary.Write(file, binary.LittleEndian, ebr)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// Deserializar EBR
-func deserializeEBR(path string, start int) (*structures.EBR, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	_, err = file.Seek(int64(start), 0)
-	if err != nil {
-		return nil, err
-	}
-
-	ebr := &structures.EBR{}
-	err = binary.Read(file, binary.LittleEndian, ebr)
-	if err != nil {
-		return nil, err
-	}
-
-	return ebr, nil
-}
-
 func createInitialEBR(filename string, start int) error {
 	// Crear el primer EBR dentro de la partición extendida
 	ebr := &structures.EBR{}
-	ebr.Part_start = int32(start + 30)
+	ebr.Part_start = int32(start + structures.EBRReserved)
 	ebr.Part_s = int32(0)
 	ebr.Part_next = int32(0)
 	copy(ebr.Part_name[:], "EBR")
 
 	// Serializar el EBR en el archivo binario en la posición de inicio de la partición extendida
-	err := serializeEBR(filename, ebr)
+	err := ebr.Serialize(filename)
 	if err != nil {
 		fmt.Println("Error al serializar el EBR:", err)
 		return err
@@ -343,7 +291,8 @@ func createLogicalPartition(fdisk *FDISK) error {
 	}
 
 	// Leer el primer EBR de la partición extendida
-	ebr, err := deserializeEBR(fdisk.path, int(extendedPartition.Part_start))
+	ebr := &structures.EBR{}
+	err = ebr.Deserialize(fdisk.path, int64(extendedPartition.Part_start))
 	if err != nil {
 		fmt.Println("Error al leer el primer EBR:", err)
 		return err
@@ -359,12 +308,12 @@ func createLogicalPartition(fdisk *FDISK) error {
 			copy(ebr.Part_name[:], fdisk.name)
 			copy(ebr.Part_fit[:], fdisk.fit)
 			fmt.Println("Partición lógica creada:", ebr)
-			_ = serializeEBR(fdisk.path, ebr)
+			_ = ebr.Serialize(fdisk.path)
 
 			break
 		}
 
-		ebr, err = deserializeEBR(fdisk.path, int(ebr.Part_next))
+		err = ebr.Deserialize(fdisk.path, int64(ebr.Part_next))
 		if err != nil {
 			fmt.Println("Error al leer el siguiente EBR:", err)
 			return err
@@ -389,145 +338,3 @@ func createLogicalPartition(fdisk *FDISK) error {
 
 	return nil
 }
-
-func GenerateFdiskReport(path string) error {
-	// Deserializar MBR
-	var mbr structures.MBR
-	err := mbr.Deserialize(path)
-	if err != nil {
-		return fmt.Errorf("error deserializando MBR: %v", err)
-	}
-
-	// Calcular el tamaño total del disco
-	totalSize := calculateTotalDiskSize(mbr)
-
-	// Iniciar la generación del contenido del archivo DOT
-	var dotContent strings.Builder
-	dotContent.WriteString("digraph DiskStructure {\n")
-	dotContent.WriteString("  node [shape=none, fontname=\"Helvetica,Arial,sans-serif\"];\n")
-	dotContent.WriteString("  rankdir=TB;\n\n")
-
-	// Crear el nodo del disco
-	dotContent.WriteString("  disk [label=<\n")
-	dotContent.WriteString("    <table border='0' cellborder='1' cellspacing='0' cellpadding='10' style='rounded' bgcolor='#F5F5F5'>\n")
-	dotContent.WriteString("      <tr>\n")
-	dotContent.WriteString("        <td colspan='2' bgcolor='#333333'><font color='white'>Disk Report</font></td>\n")
-	dotContent.WriteString("      </tr>\n")
-	dotContent.WriteString("      <tr>\n")
-
-	// Agregar MBR
-	mbrPercentage := float64(mbr.Mbr_size) / float64(totalSize) * 100
-	dotContent.WriteString(fmt.Sprintf("        <td width='%d' bgcolor='#FF6347' align='center'><b>MBR</b><br/>%.2f%%</td>\n", int(mbrPercentage), mbrPercentage))
-
-	// Agregar particiones y espacio libre
-	freeSpace := totalSize
-	for i, partition := range mbr.Mbr_partitions {
-		if partition.Part_size > 0 {
-			partitionPercentage := float64(partition.Part_size) / float64(totalSize) * 100
-			partitionType := string(partition.Part_type[:])
-			partitionName := strings.TrimRight(string(partition.Part_name[:]), "\x00")
-			color := getPartitionColor(partitionType)
-
-			dotContent.WriteString(fmt.Sprintf("        <td width='%d' bgcolor='%s' align='center'><b>%s %d</b><br/>%s<br/>%.2f%%</td>\n",
-				int(partitionPercentage), color, getPartitionTypeLabel(partitionType), i+1, partitionName, partitionPercentage))
-
-			freeSpace -= int64(partition.Part_size)
-
-			if partitionType == "E" {
-				// Agregar EBRs y particiones lógicas
-				logicalPartitions, err := getLogicalPartitions(path, int(partition.Part_start))
-				if err != nil {
-					return fmt.Errorf("error obteniendo particiones lógicas: %v", err)
-				}
-				for j, logical := range logicalPartitions {
-					// Agregar EBR (sin mostrar porcentaje)
-					dotContent.WriteString("        <td width='5' bgcolor='#FF8C00' align='center'><b>EBR</b></td>\n")
-
-					// Agregar partición lógica
-					extendedTotalSize := int64(partition.Part_size)
-					randomFloat := 1 + rand.Float64()*(2)
-					logicalPercentage := ((float64(extendedTotalSize) / float64(totalSize)) / randomFloat) * 100
-					dotContent.WriteString(fmt.Sprintf("        <td width='%d' bgcolor='#FFD700' align='center'><b>Logical %d</b><br/>%s<br/>%.2f%%</td>\n",
-						int(logicalPercentage), j+1, strings.TrimRight(string(logical.Part_name[:]), "\x00"), logicalPercentage))
-				}
-			}
-		}
-	}
-
-	// Agregar espacio libre restante
-	if freeSpace > 0 {
-		freePercentage := float64(freeSpace) / float64(totalSize) * 100
-		dotContent.WriteString(fmt.Sprintf("        <td width='%d' bgcolor='#FFFFFF' align='center'><b>Free</b><br/>%.2f%%</td>\n", int(freePercentage), freePercentage))
-	}
-
-	dotContent.WriteString("      </tr>\n")
-	dotContent.WriteString("    </table>\n")
-	dotContent.WriteString("  >];\n")
-
-	dotContent.WriteString("}\n")
-
-	// Escribir en archivo
-	outputPath := strings.TrimSuffix(path, ".mia") + "_fdisk_report.dot"
-	err = os.WriteFile(outputPath, []byte(dotContent.String()), 0644)
-	if err != nil {
-		return fmt.Errorf("error escribiendo archivo DOT: %v", err)
-	}
-
-	fmt.Printf("Reporte fdisk generado: %s\n", outputPath)
-	return nil
-}
-
-func calculateTotalDiskSize(mbr structures.MBR) int64 {
-	totalSize := int64(mbr.Mbr_size)
-	for _, partition := range mbr.Mbr_partitions {
-		totalSize += int64(partition.Part_size)
-	}
-	return totalSize
-}
-
-func getPartitionColor(partitionType string) string {
-	switch partitionType {
-	case "P":
-		return "#ADD8E6"
-	case "E":
-		return "#90EE90"
-	default:
-		return "#D3D3D3"
-	}
-}
-
-func getPartitionTypeLabel(partitionType string) string {
-	switch partitionType {
-	case "P":
-		return "Primaria"
-	case "E":
-		return "Extendida"
-	default:
-		return "Desconocida"
-	}
-}
-
-func getLogicalPartitions(path string, start int) ([]structures.EBR, error) {
-	var logicalPartitions []structures.EBR
-	ebr, err := deserializeEBR(path, start)
-	if err != nil {
-		return nil, err
-	}
-
-	for {
-		if ebr.Part_s > 0 {
-			logicalPartitions = append(logicalPartitions, *ebr)
-		}
-
-		if ebr.Part_next == 0 {
-			break
-		}
-
-		ebr, err = deserializeEBR(path, int(ebr.Part_next))
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return logicalPartitions, nil
-}
@@ -0,0 +1,186 @@
+package Commands
+
+import (
+	structures "archivos_pro1/Structures"
+	"archivos_pro1/global"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type FIND struct {
+	Path string // Carpeta donde inicia la búsqueda
+	Name string // Patrón del nombre, admite * y ?
+}
+
+/*
+   find -path=/home -name="*.txt"
+   find -path=/ -name=a?.txt
+*/
+
+func ParserFind(tokens []string) (string, error) {
+	cmd := &FIND{}
+
+	args := strings.Join(tokens, " ")
+
+	re := regexp.MustCompile(`-path="[^"]+"|-path=[^\s]+|-name="[^"]+"|-name=[^\s]+`)
+	matches := re.FindAllString(args, -1)
+
+	for _, match := range matches {
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return "", fmt.Errorf("format of parameter is invalid: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		switch key {
+		case "-path":
+			if value == "" {
+				return "", errors.New("the path cannot be empty")
+			}
+			cmd.Path = value
+		case "-name":
+			if value == "" {
+				return "", errors.New("the name cannot be empty")
+			}
+			cmd.Name = value
+		default:
+			return "", fmt.Errorf("unknown parameter: %s", key)
+		}
+	}
+
+	if cmd.Path == "" {
+		return "", errors.New("there is a missing required parameter: -path")
+	}
+
+	if cmd.Name == "" {
+		return "", errors.New("there is a missing required parameter: -name")
+	}
+
+	tree, err := commandFind(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	if tree == "" {
+		return "FIND: No matches for " + cmd.Name + " in " + cmd.Path, nil
+	}
+
+	return "FIND:\n" + tree, nil
+}
+
+func commandFind(find *FIND) (string, error) {
+	if !IsLogged {
+		return "", errors.New("you must be logged to execute this command")
+	}
+
+	mountedPartition, path, err := global.GetMountedPartition(IdPartitionGlobal)
+	if err != nil {
+		return "", err
+	}
+
+	sb := &structures.SuperBlock{}
+	err = sb.Deserialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return "", err
+	}
+
+	_, inode, err := sb.FindInode(path, find.Path)
+	if err != nil {
+		return "", err
+	}
+	if inode.I_type[0] != '0' {
+		return "", fmt.Errorf("%s no es una carpeta", find.Path)
+	}
+	if !inode.HasPermission(UidLogged, GidLogged, 4) {
+		return "", fmt.Errorf("no tienes permiso de lectura sobre %s", find.Path)
+	}
+
+	lines, err := findInFolder(sb, path, inode, strings.ToLower(find.Name), 1)
+	if err != nil {
+		return "", err
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	return strings.Join(append([]string{find.Path}, lines...), "\n"), nil
+}
+
+// findInFolder devuelve las líneas del árbol con las entradas que coinciden con el patrón,
+// incluyendo las carpetas intermedias que llevan a ellas
+func findInFolder(sb *structures.SuperBlock, path string, folder *structures.Inode, pattern string, depth int) ([]string, error) {
+	entries, err := sb.ListFolder(path, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	indent := strings.Repeat("  ", depth)
+	var lines []string
+	for _, entry := range entries {
+		child := &structures.Inode{}
+		err := child.Deserialize(path, sb.InodeOffset(entry.Inode))
+		if err != nil {
+			return nil, err
+		}
+
+		// Las entradas sin permiso de lectura no se muestran ni se recorren
+		if !child.HasPermission(UidLogged, GidLogged, 4) {
+			continue
+		}
+
+		matched := matchName(pattern, strings.ToLower(entry.Name))
+
+		var childLines []string
+		if child.I_type[0] == '0' {
+			childLines, err = findInFolder(sb, path, child, pattern, depth+1)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if matched || len(childLines) > 0 {
+			lines = append(lines, indent+entry.Name)
+			lines = append(lines, childLines...)
+		}
+	}
+
+	return lines, nil
+}
+
+// matchName indica si name coincide con el patrón, donde * reemplaza cero o más caracteres
+// y ? exactamente uno; cualquier otro carácter se compara de forma literal
+func matchName(pattern string, name string) bool {
+	p, n := []rune(pattern), []rune(name)
+	pi, ni := 0, 0
+	star, starNi := -1, 0
+
+	for ni < len(n) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == n[ni]):
+			pi++
+			ni++
+		case pi < len(p) && p[pi] == '*':
+			// Recordar el * para retroceder si lo que sigue no coincide
+			star, starNi = pi, ni
+			pi++
+		case star != -1:
+			// El * absorbe un carácter más
+			starNi++
+			pi, ni = star+1, starNi
+		default:
+			return false
+		}
+	}
+
+	// Solo pueden quedar * al final del patrón
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
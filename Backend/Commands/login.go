@@ -12,6 +12,19 @@ import (
 var IsLogged bool
 var IdPartitionGlobal string
 
+// Usuario con la sesión activa y sus identificadores según users.txt
+var UserLogged string
+var UidLogged int32
+var GidLogged int32
+
+// Logout cierra la sesión activa y olvida al usuario que la tenía
+func Logout() {
+	IsLogged = false
+	UserLogged = ""
+	UidLogged = 0
+	GidLogged = 0
+}
+
 type LOGIN struct {
 	User string
 	Pass string
@@ -95,7 +108,7 @@ func commandLogin(login *LOGIN) error {
 	sb := structures.SuperBlock{}
 	sb.Deserialize(path, int64(startOfPartition))
 	//Obtener contenido del archivo
-	content, err := sb.PrintUsersFileContent(path)
+	content, err := sb.ReadUsersFile(path)
 	if err != nil {
 		return err
 	}
@@ -103,36 +116,51 @@ func commandLogin(login *LOGIN) error {
 	fmt.Println("CONTENT: ", content)
 
 	//Comparar si el usuario y contraseña existen
-	lines := strings.Split(content, "\n")
-
-	// Iterar sobre cada línea para buscar al usuario
-	for _, line := range lines {
-		// Separar la línea por comas
-		parts := strings.Split(line, ",")
-		if len(parts) < 4 {
-			// Si la línea no tiene al menos 4 partes, no es una línea válida para usuario
-			continue
+	groups, users := structures.ParseUsersFile(content)
+
+	// Buscar al usuario entre los usuarios activos
+	user := structures.FindActiveUser(users, strings.TrimSpace(login.User))
+	if user != nil && structures.CheckPassword(user.Password, strings.TrimSpace(login.Pass)) {
+		// Obtener el grupo principal del usuario
+		group := structures.FindActiveGroup(groups, user.Group)
+		if group == nil {
+			return fmt.Errorf("el grupo %s del usuario no existe", user.Group)
 		}
 
-		// Verificar si es una línea de usuario ('U')
-		if parts[1] == "U" {
-			user := parts[3]
-			password := parts[4]
-			fmt.Println("User:", user)
-			fmt.Println("Password:", password)
-
-			// Comparar usuario y contraseña
-			if user == strings.TrimSpace(login.User) && password == strings.TrimSpace(login.Pass) {
-				fmt.Println("Login exitoso!")
-				IsLogged = true
-				return nil
+		// Las contraseñas en texto plano se reemplazan por su hash en el primer login exitoso
+		if !structures.IsPasswordHashed(user.Password) {
+			err = migratePassword(&sb, path, int64(startOfPartition), user.Name, strings.TrimSpace(login.Pass))
+			if err != nil {
+				fmt.Println("Error al migrar la contraseña:", err)
 			}
-			fmt.Println("Usuario premium: ", login.User)
-			fmt.Println("Contraseña premium: ", login.Pass)
 		}
+
+		fmt.Println("Login exitoso!")
+		IsLogged = true
+		UserLogged = user.Name
+		UidLogged = user.ID
+		GidLogged = group.ID
+		return nil
 	}
 
 	// Si no se encontró al usuario, devolver un error
 	return fmt.Errorf("usuario o contraseña incorrectos")
 
 }
+
+// migratePassword guarda el hash de la contraseña del usuario en users.txt
+func migratePassword(sb *structures.SuperBlock, path string, partitionStart int64, name string, password string) error {
+	hash, err := structures.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	err = sb.UpdateUsersFile(path, func(content string) (string, error) {
+		return structures.SetUserPassword(content, name, hash)
+	})
+	if err != nil {
+		return err
+	}
+
+	return sb.Serialize(path, partitionStart)
+}
@@ -107,20 +107,21 @@ func createDirectory(dirPath string, sb *structures.SuperBlock, partitionPath st
 	fmt.Println("Directorio destino:", destDir)
 
 	// Crear el directorio segun el path proporcionado
-	err := sb.CreateFolder(partitionPath, parentDirs, destDir, createParents)
-	if err != nil {
-		return fmt.Errorf("error al crear el directorio: %w", err)
-	}
+	createErr := sb.CreateFolder(partitionPath, parentDirs, destDir, createParents, UidLogged, GidLogged)
 
 	// Imprimir inodos y bloques
 	sb.PrintInodes(partitionPath)
 	sb.PrintBlocks(partitionPath)
 
-	// Serializar el superbloque
-	err = sb.Serialize(partitionPath, int64(mountedPartition.Part_start))
+	// Serializar el superbloque aunque la creación falle a medias, para que coincida con los bitmaps
+	err := sb.Serialize(partitionPath, int64(mountedPartition.Part_start))
 	if err != nil {
 		return fmt.Errorf("error al serializar el superbloque: %w", err)
 	}
 
+	if createErr != nil {
+		return fmt.Errorf("error al crear el directorio: %w", createErr)
+	}
+
 	return nil
 }
@@ -139,6 +139,12 @@ func createDisk(mkdisk *MKDISK, sizeBytes int) error {
 		return err
 	}
 
+	// Cerrar el disco si ya estaba abierto, porque el archivo se va a reemplazar
+	err = structures.CloseDisk(mkdisk.path)
+	if err != nil {
+		return err
+	}
+
 	// Crear el archivo binario
 	file, err := os.Create(mkdisk.path)
 	if err != nil {
@@ -105,7 +105,7 @@ func ParserMkfile(tokens []string) (string, error) {
 		return "", err
 	}
 
-	// Crear un archivo txt con la información del MKFILE
+	// Guardar un archivo txt de depuración con la información del MKFILE
 	err = CreateTxtFileWithMkfileContent(cmd)
 	if err != nil {
 		return "", err
@@ -137,7 +137,7 @@ func commandMkfile(mkfile *MKFILE) error {
 	}
 
 	// Crear el archivo
-	err = createFile(mkfile.path, mkfile.size, mkfile.cont, partitionSuperblock, partitionPath, mountedPartition, mkfile.r)
+	err = createFile(mkfile.path, mkfile.cont, partitionSuperblock, partitionPath, mountedPartition, mkfile.r)
 	if err != nil {
 		return fmt.Errorf("error al crear el archivo: %w", err)
 	}
@@ -155,58 +155,43 @@ func generateContent(size int) string {
 }
 
 // Funcion para crear un archivo
-func createFile(filePath string, size int, content string, sb *structures.SuperBlock, partitionPath string, mountedPartition *structures.Partition, createParents bool) error {
+func createFile(filePath string, content string, sb *structures.SuperBlock, partitionPath string, mountedPartition *structures.Partition, createParents bool) error {
 	fmt.Println("\nCreando archivo:", filePath)
 
 	parentDirs, destDir := utils.GetParentDirectories(filePath)
 	fmt.Println("\nDirectorios padres:", parentDirs)
 	fmt.Println("Directorio destino:", destDir)
 
-	// Obtener contenido por chunks
-	chunks := utils.SplitStringIntoChunks(content)
-	fmt.Println("\nChunks del contenido:", chunks)
-
 	// Crear el archivo
-	err := sb.CreateFile(partitionPath, parentDirs, destDir, size, chunks, createParents)
-	if err != nil {
-		return fmt.Errorf("error al crear el archivo: %w", err)
-	}
+	createErr := sb.CreateFile(partitionPath, parentDirs, destDir, content, createParents, UidLogged, GidLogged)
 
 	// Imprimir inodos y bloques
 	sb.PrintInodes(partitionPath)
 	sb.PrintBlocks(partitionPath)
 
-	// Serializar el superbloque
-	err = sb.Serialize(partitionPath, int64(mountedPartition.Part_start))
+	// Serializar el superbloque aunque la creación falle a medias, para que coincida con los bitmaps
+	err := sb.Serialize(partitionPath, int64(mountedPartition.Part_start))
 	if err != nil {
 		return fmt.Errorf("error al serializar el superbloque: %w", err)
 	}
 
+	if createErr != nil {
+		return fmt.Errorf("error al crear el archivo: %w", createErr)
+	}
+
 	return nil
 }
 
+// CreateTxtFileWithMkfileContent guarda los parámetros del MKFILE en la carpeta de artefactos de depuración
 func CreateTxtFileWithMkfileContent(mkfile *MKFILE) error {
 	// Crear el nombre del archivo txt basado en el path del MKFILE
 	txtFilename := strings.Replace(mkfile.path, "/", "_", -1) + ".txt"
 
-	// Abrir (o crear) el archivo txt
-	file, err := os.Create(txtFilename)
-	if err != nil {
-		return fmt.Errorf("error al crear el archivo txt: %w", err)
-	}
-	defer file.Close()
-
 	// Escribir la información del MKFILE en el archivo txt
 	content := fmt.Sprintf("Path: %s\n", mkfile.path)
 	content += fmt.Sprintf("Recursive: %v\n", mkfile.r)
 	content += fmt.Sprintf("Size: %d\n", mkfile.size)
 	content += fmt.Sprintf("Content:\n%s\n", mkfile.cont)
 
-	_, err = file.WriteString(content)
-	if err != nil {
-		return fmt.Errorf("error al escribir en el archivo txt: %w", err)
-	}
-
-	fmt.Printf("Archivo txt creado: %s\n", txtFilename)
-	return nil
+	return utils.WriteDebugArtifact(txtFilename, content)
 }
@@ -132,9 +132,6 @@ func commandMkfs(mkfs *MKFS) error {
 		return err
 	}
 
-	superBlock.PrintUsersFileContent(partitionPath)
-	global.GroupsUser[IdPartitionGlobal] = make(map[string][]string)
-	global.GroupsUser[IdPartitionGlobal]["root"] = []string{"root"}
 	return nil
 }
 
@@ -6,12 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 )
 
-var groupCount = 1
-
 type MKGRP struct {
 	Name string
 }
@@ -60,7 +57,7 @@ func ParserMkgrp(tokens []string) (string, error) {
 	// Ejecutar el comando MKGRP
 	err := commandMkgrp(cmd)
 	if err != nil {
-		fmt.Println("Error:", err)
+		return "", err
 	}
 
 	return "MKGRP: Group: " + cmd.Name + " created successfully", nil
@@ -87,34 +84,10 @@ func commandMkgrp(mkgrp *MKGRP) error {
 		return errorD
 	}
 
-	groupID := groupCount
-	groupCount++
-
-	groupstr := strconv.Itoa(groupID)
-
-	fmt.Println("Group ID: ", groupstr)
-
-	//Verificar si el grupo ya existe
-	if global.GroupsUser[IdPartitionGlobal][mkgrp.Name] != nil {
-		return errors.New("el grupo ya existe")
-	}
-
-	//Agregar el grupo al map global
-	structures.Groups[mkgrp.Name] = mkgrp.Name
-	global.GroupsUser[IdPartitionGlobal][mkgrp.Name] = []string{}
-	/*for key, value := range structures.Groups {
-		fmt.Println("Key:", key, "Value:", value)
-	}*/
-	fullString, err := sb.PrintUsersFileContent(path)
-	if err != nil {
-		return err
-	}
-
-	newContent := fullString + groupstr + "," + "G" + "," + strings.TrimSpace(mkgrp.Name) + "\n"
-
-	newContent = strings.ReplaceAll(newContent, "\x00", "")
-
-	err = structures.AddUserGroups(sb, path, newContent)
+	// Agregar el grupo a users.txt
+	err = sb.UpdateUsersFile(path, func(content string) (string, error) {
+		return structures.AddGroup(content, strings.TrimSpace(mkgrp.Name))
+	})
 	if err != nil {
 		return err
 	}
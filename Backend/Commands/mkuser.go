@@ -6,12 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 )
 
-var userCount = 2
-
 type MKUSER struct {
 	User string
 	Pass string
@@ -72,7 +69,7 @@ func ParserMkuser(tokens []string) (string, error) {
 
 	err := commandMkuser(cmd)
 	if err != nil {
-		fmt.Println("Error:", err)
+		return "", err
 	}
 
 	return "MKUSER: User: " + cmd.User + " created successfully", nil
@@ -100,52 +97,92 @@ func commandMkuser(mkuser *MKUSER) error {
 		return errorD
 	}
 
-	userID := userCount
-	userCount++
-
-	userIDStr := strconv.Itoa(userID)
-
-	fmt.Println("User ID: ", userIDStr)
-
-	fullString, err := sb.PrintUsersFileContent(path)
+	// La contraseña se guarda como hash con sal
+	passwordHash, err := structures.HashPassword(strings.TrimSpace(mkuser.Pass))
 	if err != nil {
 		return err
 	}
 
-	newContent := fullString + userIDStr + "," + "U" + "," + strings.TrimSpace(mkuser.Grp) + "," + strings.TrimSpace(mkuser.User) + "," + strings.TrimSpace(mkuser.Pass) + "\n"
+	// El nombre debe caber en una entrada de carpeta para crear su carpeta personal
+	if len(strings.TrimSpace(mkuser.User)) > len(structures.FolderContent{}.B_name) {
+		return fmt.Errorf("el nombre de usuario no puede exceder %d caracteres", len(structures.FolderContent{}.B_name))
+	}
 
-	newContent = strings.ReplaceAll(newContent, "\x00", "")
+	user := strings.TrimSpace(mkuser.User)
 
-	//Verificar que el 3er parametro sea un grupo existente
-	//imprimir grupos existentes
-	//fmt.Println("Grupos existentes: ", structures.Groups)
+	// Preparar el nuevo users.txt sin escribirlo, para validar el grupo y el usuario y obtener sus IDs
+	content, err := sb.ReadUsersFile(path)
+	if err != nil {
+		return err
+	}
+	content, err = structures.AddUser(content, strings.TrimSpace(mkuser.Grp), user, passwordHash)
+	if err != nil {
+		return err
+	}
+	uid, gid, err := structures.UserIDs(content, user)
+	if err != nil {
+		return err
+	}
 
-	//Verificar que el grupo exista
-	if global.GroupsUser[IdPartitionGlobal][mkuser.Grp] == nil {
-		return errors.New("el grupo no existe")
+	// La carpeta personal no debe existir antes de crear al usuario
+	homePath := homeFolderPath(user)
+	if _, _, err := sb.FindInode(path, homePath); err == nil {
+		return fmt.Errorf("ya existe la carpeta %s", homePath)
 	}
 
-	for _, value := range global.GroupsUser[IdPartitionGlobal] {
-		for _, user := range value {
-			if user == mkuser.User {
-				return errors.New("el usuario ya existe")
+	// Crear la carpeta personal antes de registrar al usuario; si users.txt no se puede
+	// escribir, la carpeta se elimina para no dejar una carpeta sin dueño
+	createErr := createHomeFolder(sb, path, user, uid, gid)
+	if createErr == nil {
+		createErr = sb.WriteUsersFile(path, content)
+		if createErr != nil {
+			if err := purgeHomeFolder(sb, path, user); err != nil {
+				fmt.Println("Error al eliminar la carpeta personal:", err)
 			}
 		}
 	}
 
-	global.GroupsUser[IdPartitionGlobal][mkuser.Grp] = append(global.GroupsUser[IdPartitionGlobal][mkuser.Grp], mkuser.User)
-
-	err = structures.AddUserGroups(sb, path, newContent)
+	// Serializar el superbloque aunque algo falle a medias, para que coincida con los bitmaps
+	err = sb.Serialize(path, int64(mountedPartition.Part_start))
 	if err != nil {
 		return err
 	}
 
-	//Serializar el superbloque
-	err = sb.Serialize(path, int64(mountedPartition.Part_start))
+	return createErr
+
+}
+
+// homeFolderPath devuelve la ruta de la carpeta personal del usuario
+func homeFolderPath(user string) string {
+	return "/home/" + strings.TrimSpace(user)
+}
+
+// createHomeFolder crea /home/<usuario> con permisos 700, propiedad del usuario uid y del grupo gid;
+// si /home no existe se crea como propiedad de root
+func createHomeFolder(sb *structures.SuperBlock, path string, user string, uid int32, gid int32) error {
+	homeIndex, home, err := sb.FindInode(path, "/home")
 	if err != nil {
-		return err
+		rootIndex, root, err := sb.FindInode(path, "/")
+		if err != nil {
+			return err
+		}
+
+		homeIndex, err = sb.MakeFolder(path, rootIndex, root, "home", 1, 1, [3]byte{'7', '7', '5'})
+		if err != nil {
+			return fmt.Errorf("error al crear /home: %w", err)
+		}
+
+		home = &structures.Inode{}
+		err = home.Deserialize(path, sb.InodeOffset(homeIndex))
+		if err != nil {
+			return err
+		}
 	}
 
-	return nil
+	_, err = sb.MakeFolder(path, homeIndex, home, user, uid, gid, [3]byte{'7', '0', '0'})
+	if err != nil {
+		return fmt.Errorf("error al crear %s: %w", homeFolderPath(user), err)
+	}
 
+	return nil
 }
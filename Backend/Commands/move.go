@@ -0,0 +1,200 @@
+package Commands
+
+import (
+	structures "archivos_pro1/Structures"
+	"archivos_pro1/global"
+	utils "archivos_pro1/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type MOVE struct {
+	Path    string // Ruta del archivo o carpeta a mover
+	Destino string // Carpeta donde se colocará
+}
+
+/*
+   move -path=/home/user/docs -destino=/home/images
+   move -path="/home/mis documentos/a.txt" -destino=/home
+*/
+
+func ParserMove(tokens []string) (string, error) {
+	cmd := &MOVE{}
+
+	args := strings.Join(tokens, " ")
+
+	re := regexp.MustCompile(`-path="[^"]+"|-path=[^\s]+|-destino="[^"]+"|-destino=[^\s]+`)
+	matches := re.FindAllString(args, -1)
+
+	for _, match := range matches {
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return "", fmt.Errorf("format of parameter is invalid: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		switch key {
+		case "-path":
+			if value == "" {
+				return "", errors.New("the path cannot be empty")
+			}
+			cmd.Path = value
+		case "-destino":
+			if value == "" {
+				return "", errors.New("the destination cannot be empty")
+			}
+			cmd.Destino = value
+		default:
+			return "", fmt.Errorf("unknown parameter: %s", key)
+		}
+	}
+
+	if cmd.Path == "" {
+		return "", errors.New("there is a missing required parameter: -path")
+	}
+
+	if cmd.Destino == "" {
+		return "", errors.New("there is a missing required parameter: -destino")
+	}
+
+	err := commandMove(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return "MOVE: " + cmd.Path + " moved to " + cmd.Destino + " successfully", nil
+}
+
+func commandMove(move *MOVE) error {
+	if !IsLogged {
+		return errors.New("you must be logged to execute this command")
+	}
+
+	mountedPartition, path, err := global.GetMountedPartition(IdPartitionGlobal)
+	if err != nil {
+		return err
+	}
+
+	sb := &structures.SuperBlock{}
+	err = sb.Deserialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return err
+	}
+
+	// Buscar la carpeta padre y la entrada a mover
+	parentDirs, name := utils.GetParentDirectories(move.Path)
+	if name == "" {
+		return errors.New("no se puede mover la carpeta raíz")
+	}
+
+	parentIndex, parent, err := sb.FindInode(path, "/"+strings.Join(parentDirs, "/"))
+	if err != nil {
+		return err
+	}
+
+	entry, err := sb.FindEntry(path, parent, name)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return fmt.Errorf("la ruta %s no existe", move.Path)
+	}
+
+	inode := &structures.Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(entry.Inode))
+	if err != nil {
+		return err
+	}
+	if !inode.HasPermission(UidLogged, GidLogged, 2) {
+		return fmt.Errorf("no tienes permiso de escritura sobre %s", move.Path)
+	}
+	err = checkUsersFileWrite(sb, path, entry.Inode)
+	if err != nil {
+		return err
+	}
+
+	// Una carpeta no puede moverse dentro de sí misma
+	if inode.I_type[0] == '0' && utils.IsSubPath(move.Path, move.Destino) {
+		return errors.New("no se puede mover una carpeta dentro de sí misma")
+	}
+
+	// Buscar la carpeta destino
+	destIndex, dest, err := sb.FindInode(path, move.Destino)
+	if err != nil {
+		return err
+	}
+	if dest.I_type[0] != '0' {
+		return fmt.Errorf("%s no es una carpeta", move.Destino)
+	}
+	if !dest.HasPermission(UidLogged, GidLogged, 2) {
+		return fmt.Errorf("no tienes permiso de escritura sobre %s", move.Destino)
+	}
+
+	existing, err := sb.FindEntry(path, dest, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("ya existe %s en %s", name, move.Destino)
+	}
+
+	// Enlazar la entrada en el destino antes de quitarla del origen
+	moveErr := sb.AddFolderEntry(path, destIndex, dest, entry.Name, entry.Inode)
+	if moveErr == nil {
+		moveErr = finishMove(sb, path, parentIndex, parent, entry, inode, destIndex, dest)
+	}
+
+	// Serializar el superbloque aunque el movimiento falle, AddFolderEntry pudo asignar bloques nuevos
+	err = sb.Serialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return err
+	}
+
+	return moveErr
+}
+
+// finishMove apunta el .. de una carpeta movida al destino y quita la entrada del origen.
+// Si algún paso falla, deshace lo anterior para que la entrada quede solo en el origen
+func finishMove(sb *structures.SuperBlock, path string, parentIndex int32, parent *structures.Inode, entry *structures.FolderEntry, inode *structures.Inode, destIndex int32, dest *structures.Inode) error {
+	isFolder := inode.I_type[0] == '0'
+
+	// Actualizar la entrada .. de la carpeta movida
+	if isFolder {
+		err := sb.SetParentEntry(path, inode, destIndex)
+		if err != nil {
+			return undoMoveEntry(sb, path, destIndex, dest, entry.Name, err)
+		}
+	}
+
+	err := sb.RemoveFolderEntry(path, parentIndex, parent, entry)
+	if err != nil {
+		if isFolder {
+			if restoreErr := sb.SetParentEntry(path, inode, parentIndex); restoreErr != nil {
+				err = fmt.Errorf("%w (y no se pudo restaurar ..: %v)", err, restoreErr)
+			}
+		}
+		return undoMoveEntry(sb, path, destIndex, dest, entry.Name, err)
+	}
+
+	return nil
+}
+
+// undoMoveEntry elimina del destino la entrada name agregada por move y devuelve moveErr,
+// junto con el error de la reversión si también falla
+func undoMoveEntry(sb *structures.SuperBlock, path string, destIndex int32, dest *structures.Inode, name string, moveErr error) error {
+	added, err := sb.FindEntry(path, dest, name)
+	if err == nil && added != nil {
+		err = sb.RemoveFolderEntry(path, destIndex, dest, added)
+	}
+	if err != nil {
+		return fmt.Errorf("%w (y no se pudo quitar %s del destino: %v)", moveErr, name, err)
+	}
+
+	return moveErr
+}
@@ -0,0 +1,131 @@
+package Commands
+
+import (
+	structures "archivos_pro1/Structures"
+	"archivos_pro1/global"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type PASSWD struct {
+	User string // Usuario al que se le cambia la contraseña (por defecto, el usuario en sesión)
+	Pass string // Nueva contraseña
+	Old  string // Contraseña actual, obligatoria al cambiar la propia
+}
+
+/*
+   passwd -old=123 -pass=nueva
+   passwd -user=user1 -pass=nueva   (solo root)
+*/
+
+func ParserPasswd(tokens []string) (string, error) {
+	cmd := &PASSWD{}
+
+	args := strings.Join(tokens, " ")
+
+	re := regexp.MustCompile(`-user="[^"]+"|-user=[^\s]+|-pass="[^"]+"|-pass=[^\s]+|-old="[^"]+"|-old=[^\s]+`)
+	matches := re.FindAllString(args, -1)
+
+	for _, match := range matches {
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return "", fmt.Errorf("format of parameter is invalid: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		switch key {
+		case "-user":
+			if value == "" {
+				return "", errors.New("the user cannot be empty")
+			}
+			cmd.User = value
+		case "-pass":
+			if value == "" {
+				return "", errors.New("the password cannot be empty")
+			}
+			cmd.Pass = value
+		case "-old":
+			cmd.Old = value
+		default:
+			return "", fmt.Errorf("unknown parameter: %s", key)
+		}
+	}
+
+	if cmd.Pass == "" {
+		return "", errors.New("there is a missing required parameter: -pass")
+	}
+
+	err := commandPasswd(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return "PASSWD: Password of " + cmd.User + " changed successfully", nil
+}
+
+func commandPasswd(passwd *PASSWD) error {
+	if !IsLogged {
+		return errors.New("you must be logged to execute this command")
+	}
+
+	// Sin -user se cambia la contraseña propia
+	if passwd.User == "" {
+		passwd.User = UserLogged
+	}
+
+	// Solo root puede cambiar la contraseña de otro usuario
+	changingOwn := passwd.User == UserLogged
+	if !changingOwn && !isRoot() {
+		return errors.New("solo root puede cambiar la contraseña de otro usuario")
+	}
+
+	mountedPartition, path, err := global.GetMountedPartition(IdPartitionGlobal)
+	if err != nil {
+		return err
+	}
+
+	sb := &structures.SuperBlock{}
+	err = sb.Deserialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return err
+	}
+
+	content, err := sb.ReadUsersFile(path)
+	if err != nil {
+		return err
+	}
+
+	_, users := structures.ParseUsersFile(content)
+	user := structures.FindActiveUser(users, passwd.User)
+	if user == nil {
+		return fmt.Errorf("el usuario %s no existe", passwd.User)
+	}
+
+	// Al cambiar la contraseña propia se debe confirmar la actual, salvo que sea root
+	if changingOwn && !isRoot() && !structures.CheckPassword(user.Password, passwd.Old) {
+		return errors.New("la contraseña actual es incorrecta")
+	}
+
+	hash, err := structures.HashPassword(passwd.Pass)
+	if err != nil {
+		return err
+	}
+
+	content, err = structures.SetUserPassword(content, passwd.User, hash)
+	if err != nil {
+		return err
+	}
+
+	err = sb.WriteUsersFile(path, content)
+	if err != nil {
+		return err
+	}
+
+	return sb.Serialize(path, int64(mountedPartition.Part_start))
+}
@@ -0,0 +1,127 @@
+package Commands
+
+import (
+	structures "archivos_pro1/Structures"
+	"archivos_pro1/global"
+	utils "archivos_pro1/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type RENAME struct {
+	Path string // Ruta del archivo o carpeta a renombrar
+	Name string // Nuevo nombre
+}
+
+/*
+   rename -path=/home/user/docs/a.txt -name=b.txt
+   rename -path="/home/mis documentos" -name=docs
+*/
+
+func ParserRename(tokens []string) (string, error) {
+	cmd := &RENAME{}
+
+	args := strings.Join(tokens, " ")
+
+	re := regexp.MustCompile(`-path="[^"]+"|-path=[^\s]+|-name="[^"]+"|-name=[^\s]+`)
+	matches := re.FindAllString(args, -1)
+
+	for _, match := range matches {
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return "", fmt.Errorf("format of parameter is invalid: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		switch key {
+		case "-path":
+			if value == "" {
+				return "", errors.New("the path cannot be empty")
+			}
+			cmd.Path = value
+		case "-name":
+			if value == "" {
+				return "", errors.New("the name cannot be empty")
+			}
+			if strings.Contains(value, "/") {
+				return "", errors.New("the name cannot contain /")
+			}
+			cmd.Name = value
+		default:
+			return "", fmt.Errorf("unknown parameter: %s", key)
+		}
+	}
+
+	if cmd.Path == "" {
+		return "", errors.New("there is a missing required parameter: -path")
+	}
+
+	if cmd.Name == "" {
+		return "", errors.New("there is a missing required parameter: -name")
+	}
+
+	err := commandRename(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return "RENAME: " + cmd.Path + " renamed to " + cmd.Name + " successfully", nil
+}
+
+func commandRename(rename *RENAME) error {
+	if !IsLogged {
+		return errors.New("you must be logged to execute this command")
+	}
+
+	mountedPartition, path, err := global.GetMountedPartition(IdPartitionGlobal)
+	if err != nil {
+		return err
+	}
+
+	sb := &structures.SuperBlock{}
+	err = sb.Deserialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return err
+	}
+
+	// Buscar la carpeta padre y la entrada a renombrar
+	parentDirs, name := utils.GetParentDirectories(rename.Path)
+	if name == "" {
+		return errors.New("no se puede renombrar la carpeta raíz")
+	}
+
+	parentIndex, parent, err := sb.FindInode(path, "/"+strings.Join(parentDirs, "/"))
+	if err != nil {
+		return err
+	}
+
+	entry, err := sb.FindEntry(path, parent, name)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return fmt.Errorf("la ruta %s no existe", rename.Path)
+	}
+
+	// Verificar el permiso de escritura sobre el archivo o carpeta
+	inode := &structures.Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(entry.Inode))
+	if err != nil {
+		return err
+	}
+	if !inode.HasPermission(UidLogged, GidLogged, 2) {
+		return fmt.Errorf("no tienes permiso de escritura sobre %s", rename.Path)
+	}
+	err = checkUsersFileWrite(sb, path, entry.Inode)
+	if err != nil {
+		return err
+	}
+
+	return sb.RenameEntry(path, parentIndex, parent, entry, rename.Name)
+}
@@ -5,26 +5,34 @@ import (
 	"archivos_pro1/reports"
 	"errors"
 	"fmt"
-	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
 type REP struct {
-	id   string // ID del disco
-	path string // Ruta del archivo del disco
-	name string // Nombre del reporte
-	ruta string // Ruta del archivo ls (opcional)
+	id      string // ID del disco
+	path    string // Ruta del archivo del disco
+	name    string // Nombre del reporte
+	ruta    string // Ruta del archivo ls (opcional)
+	format  string // Formato de salida: vacío para imagen/texto o json
+	width   int    // Registros por línea de los reportes de bitmap (opcional)
+	summary bool   // Agregar el resumen de ocupación a los reportes de bitmap
+	offset  int64  // Byte inicial del reporte hex, o -1
+	length  int64  // Cantidad de bytes del reporte hex
+	inode   int32  // Inodo a volcar en el reporte hex, o -1
+	block   int32  // Bloque a volcar en el reporte hex, o -1
+	sb      bool   // Volcar el superbloque en el reporte hex
 }
 
 // ParserRep parsea el comando rep y devuelve una instancia de REP
 func ParserRep(tokens []string) (string, error) {
-	cmd := &REP{} // Crea una nueva instancia de REP
+	cmd := &REP{offset: -1, inode: -1, block: -1} // Crea una nueva instancia de REP
 
 	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
 	args := strings.Join(tokens, " ")
 	// Expresión regular para encontrar los parámetros del comando rep
-	re := regexp.MustCompile(`-id=[^\s]+|-path="[^"]+"|-path=[^\s]+|-name=[^\s]+|-ruta="[^"]+"|-ruta=[^\s]+`)
+	re := regexp.MustCompile(`-id=[^\s]+|-path="[^"]+"|-path=[^\s]+|-name=[^\s]+|-ruta="[^"]+"|-ruta=[^\s]+|-format=[^\s]+|-width=[^\s]+|-summary|-offset=[^\s]+|-length=[^\s]+|-inode=[^\s]+|-block=[^\s]+|-sb`)
 	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
 	matches := re.FindAllString(args, -1)
 
@@ -32,10 +40,13 @@ func ParserRep(tokens []string) (string, error) {
 	for _, match := range matches {
 		// Divide cada parte en clave y valor usando "=" como delimitador
 		kv := strings.SplitN(match, "=", 2)
-		if len(kv) != 2 {
+		key := strings.ToLower(kv[0])
+		var value string
+		if len(kv) == 2 {
+			value = kv[1]
+		} else if key != "-summary" && key != "-sb" {
 			return "", fmt.Errorf("formato de parámetro inválido: %s", match)
 		}
-		key, value := strings.ToLower(kv[0]), kv[1]
 
 		// Remove quotes from value if present
 		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
@@ -58,13 +69,57 @@ func ParserRep(tokens []string) (string, error) {
 			cmd.path = value
 		case "-name":
 			// Verifica que el nombre sea uno de los valores permitidos
-			validNames := []string{"mbr", "disk", "inode", "block", "bm_inode", "bm_block", "sb", "file", "ls"}
+			validNames := []string{"mbr", "disk", "inode", "block", "bm_inode", "bm_block", "sb", "file", "ls", "tree", "users", "hex"}
 			if !contains(validNames, value) {
-				return "", errors.New("name must be one of: mbr, disk, inode, block, bm_inode, bm_block, sb, file, ls")
+				return "", errors.New("name must be one of: mbr, disk, inode, block, bm_inode, bm_block, sb, file, ls, tree, users, hex")
 			}
 			cmd.name = value
 		case "-ruta":
 			cmd.ruta = value
+		case "-format":
+			// Verifica que el formato sea uno de los valores permitidos
+			value = strings.ToLower(value)
+			if value != "json" {
+				return "", errors.New("format must be: json")
+			}
+			cmd.format = value
+		case "-width":
+			// Verifica que el ancho sea un entero positivo
+			width, err := strconv.Atoi(value)
+			if err != nil || width <= 0 {
+				return "", errors.New("width must be a positive integer")
+			}
+			cmd.width = width
+		case "-summary":
+			cmd.summary = true
+		case "-offset":
+			// Verifica que el offset sea un entero no negativo
+			offset, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || offset < 0 {
+				return "", errors.New("offset must be a non-negative integer")
+			}
+			cmd.offset = offset
+		case "-length":
+			// Verifica que la longitud sea un entero positivo
+			length, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || length <= 0 {
+				return "", errors.New("length must be a positive integer")
+			}
+			cmd.length = length
+		case "-inode":
+			inode, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || inode < 0 {
+				return "", errors.New("inode must be a non-negative integer")
+			}
+			cmd.inode = int32(inode)
+		case "-block":
+			block, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || block < 0 {
+				return "", errors.New("block must be a non-negative integer")
+			}
+			cmd.block = int32(block)
+		case "-sb":
+			cmd.sb = true
 		default:
 			// Si el parámetro no es reconocido, devuelve un error
 			return "", fmt.Errorf("unknown parameter: %s", key)
@@ -76,10 +131,34 @@ func ParserRep(tokens []string) (string, error) {
 		return "", errors.New("there are missing required parameters")
 	}
 
+	// -width y -summary solo aplican a los reportes de bitmap
+	if (cmd.width != 0 || cmd.summary) && cmd.name != "bm_inode" && cmd.name != "bm_block" {
+		return "", errors.New("-width and -summary only apply to the bm_inode and bm_block reports")
+	}
+
+	// El reporte hex necesita exactamente una región: -offset con -length, -inode, -block o -sb
+	regions := 0
+	for _, set := range []bool{cmd.offset != -1 || cmd.length != 0, cmd.inode != -1, cmd.block != -1, cmd.sb} {
+		if set {
+			regions++
+		}
+	}
+	if cmd.name != "hex" && regions > 0 {
+		return "", errors.New("-offset, -length, -inode, -block and -sb only apply to the hex report")
+	}
+	if cmd.name == "hex" {
+		if regions != 1 {
+			return "", errors.New("the hex report requires exactly one of: -offset with -length, -inode, -block, -sb")
+		}
+		if (cmd.offset == -1) != (cmd.length == 0) {
+			return "", errors.New("-offset and -length must be used together")
+		}
+	}
+
 	// Aquí se puede agregar la lógica para ejecutar el comando rep con los parámetros proporcionados
 	err := commandRep(cmd)
 	if err != nil {
-		fmt.Println("Error:", err)
+		return "", err
 	}
 
 	return "REP: Report for " + cmd.name + " created successfully", nil
@@ -103,62 +182,56 @@ func commandRep(rep *REP) error {
 		return err
 	}
 
+	// Los reportes siempre se escriben dentro de la carpeta raíz de reportes
+	rep.path = reports.ResolvePath(rep.path)
+
 	// Switch para manejar diferentes tipos de reportes
 	switch rep.name {
 	case "mbr":
-		err = reports.ReportMBR(mountedMbr, rep.path)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
+		err = reports.ReportMBR(mountedMbr, mountedDiskPath, rep.path, rep.format)
 	case "inode":
-		err = reports.ReportInode(mountedSb, mountedDiskPath, rep.path)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
+		err = reports.ReportInode(mountedSb, mountedDiskPath, rep.path, rep.format)
 	case "bm_inode":
-		err = reports.ReportBMInode(mountedSb, mountedDiskPath, rep.path)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
+		err = reports.ReportBMInode(mountedSb, mountedDiskPath, rep.path, rep.format, reports.BitmapOptions{Width: rep.width, Summary: rep.summary})
 
 	case "disk":
+		err = reports.ReportDisk(mountedMbr, mountedDiskPath, rep.path, rep.format)
 
-		dotPath := strings.TrimSuffix(mountedDiskPath, ".mia") + "_fdisk_report.dot"
-		imgPath := strings.TrimSuffix(mountedDiskPath, ".mia") + "_fdisk_report.png"
+	case "sb":
+		err = reports.ReportSuperblock(mountedSb, rep.path, rep.format)
 
-		// Comando para convertir el archivo DOT en una imagen usando Graphviz
-		cmd := exec.Command("dot", "-Tpng", dotPath, "-o", imgPath)
-		err = cmd.Run()
-		if err != nil {
-			return fmt.Errorf("error generating disk image: %v", err)
-		}
+	case "block":
+		err = reports.ReportBlock(mountedSb, mountedDiskPath, rep.path, rep.format)
 
-		// Mensaje de éxito
-		fmt.Printf("Imagen del reporte de disco creada en: %s\n", imgPath)
+	case "bm_block":
+		err = reports.ReportBMBlock(mountedSb, mountedDiskPath, rep.path, rep.format, reports.BitmapOptions{Width: rep.width, Summary: rep.summary})
 
-	case "sb":
-		err = reports.ReportSuperblock(mountedSb, rep.path)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
+	case "tree":
+		err = reports.ReportTree(mountedSb, mountedDiskPath, rep.path, rep.format)
 
-	case "block":
-		err = reports.ReportBlock(mountedSb, mountedDiskPath, rep.path)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
+	case "users":
+		err = reports.ReportUsers(mountedSb, mountedDiskPath, rep.path, rep.format)
+
+	case "hex":
+		partition, _ := mountedMbr.GetPartitionByID(rep.id)
+		if partition == nil {
+			return fmt.Errorf("no se encontró la partición %s", rep.id)
 		}
+		region := reports.HexRegion{Offset: rep.offset, Length: rep.length, Inode: rep.inode, Block: rep.block, SuperBlock: rep.sb}
+		err = reports.ReportHex(mountedMbr, mountedSb, int64(partition.Part_start), mountedDiskPath, rep.path, region, rep.format)
 
-	case "bm_block":
-		err = reports.ReportBMBlock(mountedSb, mountedDiskPath, rep.path)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
+	case "ls":
+		if rep.ruta == "" {
+			return errors.New("the ls report requires the -ruta parameter")
 		}
+		err = reports.ReportLs(mountedSb, mountedDiskPath, rep.path, rep.ruta, rep.format)
 
 	case "file":
-		//Retornar solo un mensaje de éxito
-		fmt.Println("Reporte de archivo creado exitosamente")
-
+		if rep.ruta == "" {
+			return errors.New("the file report requires the -ruta parameter")
+		}
+		err = reports.ReportFile(mountedSb, mountedDiskPath, rep.path, rep.ruta, rep.format)
 	}
 
-	return nil
+	return err
 }
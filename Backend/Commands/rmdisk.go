@@ -1,6 +1,7 @@
 package Commands
 
 import (
+	structures "archivos_pro1/Structures"
 	"bufio"
 	"errors" // Paquete para manejar errores y crear nuevos errores con mensajes personalizados
 	"fmt"    // Paquete para formatear cadenas y realizar operaciones de entrada/salida
@@ -90,8 +91,13 @@ func commandRmdisk(rmdisk *RMDISK) error {
 		return errors.New("deletion canceled by user")
 	}
 
-	// Elimina el archivo del disco
-	err := os.Remove(rmdisk.path)
+	// Cierra el disco si estaba abierto y elimina el archivo
+	err := structures.CloseDisk(rmdisk.path)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(rmdisk.path)
 	if err != nil {
 		return err
 	}
@@ -68,6 +68,11 @@ func commandRmgrp(cmd *RMGRP) error {
 		return errors.New("necesitas iniciar sesión para ejecutar este comando")
 	}
 
+	// El grupo root no se puede eliminar
+	if cmd.Name == rootGroup {
+		return errors.New("no se puede eliminar el grupo root")
+	}
+
 	mountedPartition, path, err := global.GetMountedPartition(IdPartitionGlobal)
 	if err != nil {
 		return err
@@ -81,39 +86,19 @@ func commandRmgrp(cmd *RMGRP) error {
 		return err
 	}
 
-	var fullString string
-	fullString, err = sb.PrintUsersFileContent(path)
+	// Marcar como eliminados al grupo y a sus usuarios en users.txt
+	err = sb.UpdateUsersFile(path, func(content string) (string, error) {
+		return structures.RemoveGroup(content, cmd.Name)
+	})
 	if err != nil {
 		return err
 	}
 
-	// Verificar si el grupo existe
-	if _, exists := global.GroupsUser[IdPartitionGlobal][cmd.Name]; !exists {
-		return errors.New("el grupo no existe")
-	}
-
-	// Actualizar el archivo users.txt para marcar el grupo como eliminado
-	err = sb.UpdateUsersFile(path, cmd.Name, fullString)
-
-	var verificationGroup = global.GroupsUser[IdPartitionGlobal][cmd.Name]
-
-	for _, user := range verificationGroup {
-		fullString, err = sb.PrintUsersFileContent(path)
-		if err != nil {
-			return err
-		}
-		fmt.Println("Usuario: ", user)
-		sb.UpdateUsersFileUsers(path, user, fullString)
-	}
-
+	err = sb.Serialize(path, int64(mountedPartition.Part_start))
 	if err != nil {
 		return err
 	}
 
-	// Eliminar el grupo del map global
-	delete(global.GroupsUser[IdPartitionGlobal], cmd.Name)
-	fmt.Println("Nombre eliminado: ", cmd.Name)
-
 	fmt.Println("Grupo eliminado correctamente")
 
 	return nil
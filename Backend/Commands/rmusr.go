@@ -10,7 +10,8 @@ import (
 )
 
 type RMUSR struct {
-	User string
+	User  string
+	Purge bool // Eliminar también la carpeta personal /home/<usuario>
 }
 
 func ParserRmusr(tokens []string) (string, error) {
@@ -18,15 +19,16 @@ func ParserRmusr(tokens []string) (string, error) {
 
 	args := strings.Join(tokens, " ")
 
-	re := regexp.MustCompile(`-user="[^"]+"|-user=[^\s]+`)
+	re := regexp.MustCompile(`-user="[^"]+"|-user=[^\s]+|-purge`)
 	matches := re.FindAllString(args, -1)
 
 	for _, match := range matches {
 		kv := strings.SplitN(match, "=", 2)
-		if len(kv) != 2 {
-			return "", fmt.Errorf("format of parameter is invalid: %s", match)
+		key := strings.ToLower(kv[0])
+		var value string
+		if len(kv) == 2 {
+			value = kv[1]
 		}
-		key, value := strings.ToLower(kv[0]), kv[1]
 
 		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
 			value = strings.Trim(value, "\"")
@@ -38,6 +40,8 @@ func ParserRmusr(tokens []string) (string, error) {
 				return "", errors.New("the name of the user cannot be empty")
 			}
 			cmd.User = value
+		case "-purge":
+			cmd.Purge = true
 		default:
 			return "", fmt.Errorf("unknown parameter found: %s", key)
 		}
@@ -60,6 +64,11 @@ func commandRmusr(cmd *RMUSR) error {
 		return errors.New("necesitas iniciar sesión para ejecutar este comando")
 	}
 
+	// El usuario root no se puede eliminar
+	if cmd.User == rootUser {
+		return errors.New("no se puede eliminar el usuario root")
+	}
+
 	mountedPartition, path, err := global.GetMountedPartition(IdPartitionGlobal)
 	if err != nil {
 		return err
@@ -71,25 +80,50 @@ func commandRmusr(cmd *RMUSR) error {
 	if err != nil {
 		return err
 	}
-	var fullString string
-	fullString, err = sb.PrintUsersFileContent(path)
+	// Marcar al usuario como eliminado en users.txt
+	rmErr := sb.UpdateUsersFile(path, func(content string) (string, error) {
+		return structures.RemoveUser(content, cmd.User)
+	})
+
+	// Eliminar la carpeta personal si se pidió
+	if rmErr == nil && cmd.Purge {
+		rmErr = purgeHomeFolder(sb, path, cmd.User)
+	}
+
+	// Serializar el superbloque aunque la eliminación falle a medias, para que coincida con los bitmaps
+	err = sb.Serialize(path, int64(mountedPartition.Part_start))
 	if err != nil {
 		return err
 	}
+	if rmErr != nil {
+		return rmErr
+	}
 
-	// Actualizar el archivo users.txt para marcar el grupo como eliminado
+	fmt.Println("Usuario eliminado correctamente")
 
-	err = sb.UpdateUsersFileUsers(path, cmd.User, fullString)
-	fmt.Println("Path: ", path)
+	return nil
+}
+
+// purgeHomeFolder elimina /home/<usuario> con todo su contenido, si existe
+func purgeHomeFolder(sb *structures.SuperBlock, path string, user string) error {
+	homeIndex, home, err := sb.FindInode(path, "/home")
 	if err != nil {
-		return err
+		return nil
 	}
 
-	// Eliminar el grupo del map global
-	delete(global.GroupsUser[IdPartitionGlobal], cmd.User)
-	fmt.Println("Nombre eliminado: ", cmd.User)
+	entry, err := sb.FindEntry(path, home, user)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		fmt.Println("El usuario no tiene carpeta personal:", homeFolderPath(user))
+		return nil
+	}
 
-	fmt.Println("Grupo eliminado correctamente")
+	err = sb.RemoveInode(path, entry.Inode)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return sb.RemoveFolderEntry(path, homeIndex, home, entry)
 }
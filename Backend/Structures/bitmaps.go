@@ -1,106 +1,157 @@
 package structures
 
 import (
-	"encoding/binary"
+	"bytes"
+	"errors"
 	"fmt"
-	"os"
 )
 
 // CreateBitMaps crea los Bitmaps de inodos y bloques en el archivo especificado
 func (sb *SuperBlock) CreateBitMaps(path string) error {
-	// Escribir Bitmaps
-	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
+	// Bitmap de inodos: n '0'
+	fmt.Println("S_free_inodes_count:", sb.S_free_inodes_count)
+	err := writeDiskAt(path, bytes.Repeat([]byte{'0'}, int(sb.S_free_inodes_count)), int64(sb.S_bm_inode_start))
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	// Bitmap de inodos
-	// Mover el puntero del archivo a la posición especificada
-	_, err = file.Seek(int64(sb.S_bm_inode_start), 0)
+	// Bitmap de bloques: n 'O'
+	return writeDiskAt(path, bytes.Repeat([]byte{'O'}, int(sb.S_free_blocks_count)), int64(sb.S_bm_block_start))
+}
+
+// readBitmap lee completo un bitmap de count bytes que inicia en start
+func readBitmap(path string, start int32, count int32) ([]byte, error) {
+	buffer := make([]byte, count)
+	err := ReadDiskAt(path, buffer, int64(start))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Crear un buffer de n '0'
-	fmt.Println("S_free_inodes_count:", sb.S_free_inodes_count)
-	buffer := make([]byte, sb.S_free_inodes_count)
-	for i := range buffer {
-		buffer[i] = '0'
-	}
+	return buffer, nil
+}
 
-	// Escribir el buffer en el archivo
-	err = binary.Write(file, binary.LittleEndian, buffer)
-	if err != nil {
-		return err
+// writeBitmapByte escribe un solo byte del bitmap en la posición indicada
+func writeBitmapByte(path string, start int32, index int32, value byte) error {
+	return writeDiskAt(path, []byte{value}, int64(start+index))
+}
+
+// nextFree devuelve el índice del primer byte libre a partir de from, o len(bitmap) si no hay
+func nextFree(bitmap []byte, from int32, free byte) int32 {
+	for i := from; i < int32(len(bitmap)); i++ {
+		if bitmap[i] == free {
+			return i
+		}
 	}
+	return int32(len(bitmap))
+}
 
-	// Bitmap de bloques
-	// Mover el puntero del archivo a la posición especificada
-	_, err = file.Seek(int64(sb.S_bm_block_start), 0)
+// AllocateInode busca el primer inodo libre en el bitmap, lo marca como usado y devuelve su índice
+func (sb *SuperBlock) AllocateInode(path string) (int32, error) {
+	bitmap, err := sb.ReadInodeBitmap(path)
 	if err != nil {
-		return err
+		return -1, err
 	}
 
-	// Crear un buffer de n 'O'
-	buffer = make([]byte, sb.S_free_blocks_count)
-	for i := range buffer {
-		buffer[i] = 'O'
+	index := nextFree(bitmap, 0, '0')
+	if index == int32(len(bitmap)) {
+		return -1, errors.New("no hay inodos libres")
 	}
 
-	// Escribir el buffer en el archivo
-	err = binary.Write(file, binary.LittleEndian, buffer)
+	err = writeBitmapByte(path, sb.S_bm_inode_start, index, '1')
 	if err != nil {
-		return err
+		return -1, err
 	}
 
-	return nil
+	// Actualizar el superbloque
+	sb.S_inodes_count++
+	sb.S_free_inodes_count--
+	sb.S_first_ino = sb.S_inode_start + nextFree(bitmap, index+1, '0')*sb.S_inode_size
+
+	return index, nil
 }
 
-// Actualizar Bitmap de inodos
-func (sb *SuperBlock) UpdateBitmapInode(path string) error {
-	// Abrir el archivo
-	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+// AllocateBlock busca el primer bloque libre en el bitmap, lo marca como usado y devuelve su índice
+func (sb *SuperBlock) AllocateBlock(path string) (int32, error) {
+	bitmap, err := sb.ReadBlockBitmap(path)
 	if err != nil {
-		return err
+		return -1, err
+	}
+
+	index := nextFree(bitmap, 0, 'O')
+	if index == int32(len(bitmap)) {
+		return -1, errors.New("no hay bloques libres")
 	}
-	defer file.Close()
 
-	// Mover el puntero del archivo a la posición del bitmap de inodos
-	_, err = file.Seek(int64(sb.S_bm_inode_start)+int64(sb.S_inodes_count), 0)
+	err = writeBitmapByte(path, sb.S_bm_block_start, index, 'X')
 	if err != nil {
-		return err
+		return -1, err
 	}
 
-	// Escribir el bit en el archivo
-	_, err = file.Write([]byte{'1'})
+	// Actualizar el superbloque
+	sb.S_blocks_count++
+	sb.S_free_blocks_count--
+	sb.S_first_blo = sb.S_block_start + nextFree(bitmap, index+1, 'O')*sb.S_block_size
+
+	return index, nil
+}
+
+// FreeInode marca el inodo como libre en el bitmap
+func (sb *SuperBlock) FreeInode(path string, index int32) error {
+	err := writeBitmapByte(path, sb.S_bm_inode_start, index, '0')
 	if err != nil {
 		return err
 	}
 
+	// Actualizar el superbloque
+	sb.S_inodes_count--
+	sb.S_free_inodes_count++
+	if offset := sb.InodeOffset(index); offset < int64(sb.S_first_ino) {
+		sb.S_first_ino = int32(offset)
+	}
+
 	return nil
 }
 
-// Actualizar Bitmap de bloques
-func (sb *SuperBlock) UpdateBitmapBlock(path string) error {
-	// Abrir el archivo
-	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+// FreeBlock marca el bloque como libre en el bitmap
+func (sb *SuperBlock) FreeBlock(path string, index int32) error {
+	err := writeBitmapByte(path, sb.S_bm_block_start, index, 'O')
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	// Mover el puntero del archivo a la posición del bitmap de bloques
-	_, err = file.Seek(int64(sb.S_bm_block_start)+int64(sb.S_blocks_count), 0)
-	if err != nil {
-		return err
+	// Actualizar el superbloque
+	sb.S_blocks_count--
+	sb.S_free_blocks_count++
+	if offset := sb.BlockOffset(index); offset < int64(sb.S_first_blo) {
+		sb.S_first_blo = int32(offset)
 	}
 
-	// Escribir el bit en el archivo
-	_, err = file.Write([]byte{'X'})
+	return nil
+}
+
+// ReadInodeBitmap lee en una sola operación el bitmap de inodos completo
+func (sb *SuperBlock) ReadInodeBitmap(path string) ([]byte, error) {
+	return readBitmap(path, sb.S_bm_inode_start, sb.S_inodes_count+sb.S_free_inodes_count)
+}
+
+// ReadBlockBitmap lee en una sola operación el bitmap de bloques completo
+func (sb *SuperBlock) ReadBlockBitmap(path string) ([]byte, error) {
+	return readBitmap(path, sb.S_bm_block_start, sb.S_blocks_count+sb.S_free_blocks_count)
+}
+
+// UsedInodes devuelve los índices de los inodos marcados como usados en el bitmap
+func (sb *SuperBlock) UsedInodes(path string) ([]int32, error) {
+	bitmap, err := sb.ReadInodeBitmap(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	var used []int32
+	for i, value := range bitmap {
+		if value == '1' {
+			used = append(used, int32(i))
+		}
+	}
+
+	return used, nil
 }
@@ -0,0 +1,200 @@
+package structures
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Disk es el acceso por posición a un disco .mia; todas las estructuras se leen y escriben a través de él
+type Disk interface {
+	ReadAt(p []byte, offset int64) (int, error)
+	WriteAt(p []byte, offset int64) (int, error)
+	Size() (int64, error)
+	Close() error
+}
+
+// FileDisk es un Disk respaldado por un archivo abierto una sola vez
+type FileDisk struct {
+	file *os.File
+}
+
+// ReadAt lee len(p) bytes del archivo a partir de offset
+func (d *FileDisk) ReadAt(p []byte, offset int64) (int, error) {
+	return d.file.ReadAt(p, offset)
+}
+
+// WriteAt escribe p en el archivo a partir de offset
+func (d *FileDisk) WriteAt(p []byte, offset int64) (int, error) {
+	return d.file.WriteAt(p, offset)
+}
+
+// Size devuelve el tamaño actual del archivo en bytes
+func (d *FileDisk) Size() (int64, error) {
+	info, err := d.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// Close cierra el archivo
+func (d *FileDisk) Close() error {
+	return d.file.Close()
+}
+
+// MemoryDisk es un Disk de tamaño fijo que se mantiene en memoria, útil para pruebas
+type MemoryDisk struct {
+	mu   sync.RWMutex
+	data []byte
+}
+
+// NewMemoryDisk crea un disco en memoria de size bytes inicializado en ceros
+func NewMemoryDisk(size int64) *MemoryDisk {
+	return &MemoryDisk{data: make([]byte, size)}
+}
+
+// ReadAt lee len(p) bytes del disco a partir de offset
+func (d *MemoryDisk) ReadAt(p []byte, offset int64) (int, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if offset < 0 || offset+int64(len(p)) > int64(len(d.data)) {
+		return 0, fmt.Errorf("la lectura %d-%d sale del disco de %d bytes", offset, offset+int64(len(p)), len(d.data))
+	}
+	return copy(p, d.data[offset:]), nil
+}
+
+// WriteAt escribe p en el disco a partir de offset; el disco no crece
+func (d *MemoryDisk) WriteAt(p []byte, offset int64) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if offset < 0 || offset+int64(len(p)) > int64(len(d.data)) {
+		return 0, fmt.Errorf("la escritura %d-%d sale del disco de %d bytes", offset, offset+int64(len(p)), len(d.data))
+	}
+	return copy(d.data[offset:], p), nil
+}
+
+// Size devuelve el tamaño del disco en bytes
+func (d *MemoryDisk) Size() (int64, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return int64(len(d.data)), nil
+}
+
+// Close no libera nada; el contenido se conserva mientras exista el MemoryDisk
+func (d *MemoryDisk) Close() error {
+	return nil
+}
+
+// Discos abiertos por ruta, para no abrir el archivo en cada lectura o escritura
+var (
+	disksMu sync.Mutex
+	disks   = make(map[string]Disk)
+)
+
+// OpenDisk devuelve el disco abierto para path, abriendo el archivo la primera vez
+func OpenDisk(path string) (Disk, error) {
+	disksMu.Lock()
+	defer disksMu.Unlock()
+
+	if disk, ok := disks[path]; ok {
+		return disk, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	disk := &FileDisk{file: file}
+	disks[path] = disk
+	return disk, nil
+}
+
+// AttachDisk registra disk bajo path, de modo que los comandos sobre path lo utilicen
+// en lugar del archivo (por ejemplo, un MemoryDisk en pruebas)
+func AttachDisk(path string, disk Disk) error {
+	err := CloseDisk(path)
+	if err != nil {
+		return err
+	}
+
+	disksMu.Lock()
+	defer disksMu.Unlock()
+	disks[path] = disk
+	return nil
+}
+
+// CloseDisk cierra el disco abierto para path, si lo hay; debe llamarse antes de
+// reemplazar o eliminar el archivo
+func CloseDisk(path string) error {
+	disksMu.Lock()
+	defer disksMu.Unlock()
+
+	disk, ok := disks[path]
+	if !ok {
+		return nil
+	}
+	delete(disks, path)
+	return disk.Close()
+}
+
+// ReadDiskAt lee exactamente len(buffer) bytes del disco path a partir de offset
+func ReadDiskAt(path string, buffer []byte, offset int64) error {
+	disk, err := OpenDisk(path)
+	if err != nil {
+		return err
+	}
+
+	n, err := disk.ReadAt(buffer, offset)
+	if n < len(buffer) {
+		if err == nil {
+			err = errors.New("lectura incompleta")
+		}
+		return fmt.Errorf("error al leer %d bytes en %d: %w", len(buffer), offset, err)
+	}
+	return nil
+}
+
+// writeDiskAt escribe buffer en el disco path a partir de offset
+func writeDiskAt(path string, buffer []byte, offset int64) error {
+	disk, err := OpenDisk(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = disk.WriteAt(buffer, offset)
+	return err
+}
+
+// readStruct deserializa data (en little endian) desde el disco path a partir de offset
+func readStruct(path string, offset int64, data any) error {
+	size := binary.Size(data)
+	if size <= 0 {
+		return fmt.Errorf("tamaño de estructura inválido: %d", size)
+	}
+
+	buffer := make([]byte, size)
+	err := ReadDiskAt(path, buffer, offset)
+	if err != nil {
+		return err
+	}
+
+	return binary.Read(bytes.NewReader(buffer), binary.LittleEndian, data)
+}
+
+// writeStruct serializa data (en little endian) en el disco path a partir de offset
+func writeStruct(path string, offset int64, data any) error {
+	var buffer bytes.Buffer
+	err := binary.Write(&buffer, binary.LittleEndian, data)
+	if err != nil {
+		return err
+	}
+
+	return writeDiskAt(path, buffer.Bytes(), offset)
+}
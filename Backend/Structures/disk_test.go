@@ -0,0 +1,115 @@
+package structures
+
+import (
+	"testing"
+)
+
+// attachMemoryDisk registra un disco en memoria de size bytes bajo una ruta ficticia
+func attachMemoryDisk(t *testing.T, size int64) string {
+	t.Helper()
+
+	path := "/memoria/" + t.Name() + ".mia"
+	if err := AttachDisk(path, NewMemoryDisk(size)); err != nil {
+		t.Fatalf("AttachDisk: %v", err)
+	}
+	t.Cleanup(func() { CloseDisk(path) })
+
+	return path
+}
+
+func TestMemoryDiskRoundTrip(t *testing.T) {
+	path := attachMemoryDisk(t, 4096)
+
+	mbr := MBR{Mbr_size: 4096, Mbr_creation_date: 1700000000, Mbr_disk_signature: 42, Mbr_disk_fit: [1]byte{'F'}}
+	mbr.Mbr_partitions[0] = Partition{Part_status: [1]byte{'0'}, Part_type: [1]byte{'P'}, Part_start: 200, Part_size: 1000, Part_correlative: 1}
+	copy(mbr.Mbr_partitions[0].Part_name[:], "Part1")
+
+	sb := SuperBlock{S_filesystem_type: 2, S_inodes_count: 3, S_blocks_count: 5, S_free_inodes_count: 7, S_magic: 0xEF53, S_inode_size: 88, S_block_size: 64}
+
+	inode := Inode{I_uid: 1, I_gid: 1, I_size: 27, I_type: [1]byte{'1'}, I_perm: [3]byte{'6', '6', '4'}}
+	for i := range inode.I_block {
+		inode.I_block[i] = -1
+	}
+	inode.I_block[0] = 3
+
+	folder := FolderBlock{}
+	copy(folder.B_content[0].B_name[:], ".")
+	copy(folder.B_content[2].B_name[:], "users.txt")
+	folder.B_content[2].B_inodo = 1
+	folder.B_content[3].B_inodo = -1
+
+	pointer := PointerBlock{}
+	for i := range pointer.P_pointers {
+		pointer.P_pointers[i] = int32(i * 2)
+	}
+
+	if err := mbr.Serialize(path); err != nil {
+		t.Fatalf("MBR.Serialize: %v", err)
+	}
+	if err := sb.Serialize(path, 200); err != nil {
+		t.Fatalf("SuperBlock.Serialize: %v", err)
+	}
+	if err := inode.Serialize(path, 400); err != nil {
+		t.Fatalf("Inode.Serialize: %v", err)
+	}
+	if err := folder.Serialize(path, 600); err != nil {
+		t.Fatalf("FolderBlock.Serialize: %v", err)
+	}
+	if err := pointer.Serialize(path, 700); err != nil {
+		t.Fatalf("PointerBlock.Serialize: %v", err)
+	}
+
+	gotMBR := MBR{}
+	if err := gotMBR.Deserialize(path); err != nil || gotMBR != mbr {
+		t.Errorf("MBR: se obtuvo %+v (%v), se esperaba %+v", gotMBR, err, mbr)
+	}
+	gotSB := SuperBlock{}
+	if err := gotSB.Deserialize(path, 200); err != nil || gotSB != sb {
+		t.Errorf("SuperBlock: se obtuvo %+v (%v), se esperaba %+v", gotSB, err, sb)
+	}
+	gotInode := Inode{}
+	if err := gotInode.Deserialize(path, 400); err != nil || gotInode != inode {
+		t.Errorf("Inode: se obtuvo %+v (%v), se esperaba %+v", gotInode, err, inode)
+	}
+	gotFolder := FolderBlock{}
+	if err := gotFolder.Deserialize(path, 600); err != nil || gotFolder != folder {
+		t.Errorf("FolderBlock: se obtuvo %+v (%v), se esperaba %+v", gotFolder, err, folder)
+	}
+	gotPointer := PointerBlock{}
+	if err := gotPointer.Deserialize(path, 700); err != nil || gotPointer != pointer {
+		t.Errorf("PointerBlock: se obtuvo %+v (%v), se esperaba %+v", gotPointer, err, pointer)
+	}
+}
+
+func TestMemoryDiskOutOfRange(t *testing.T) {
+	disk := NewMemoryDisk(128)
+
+	for _, offset := range []int64{-1, 100, 128} {
+		if _, err := disk.ReadAt(make([]byte, 64), offset); err == nil {
+			t.Errorf("ReadAt en %d debió fallar en un disco de 128 bytes", offset)
+		}
+		if _, err := disk.WriteAt(make([]byte, 64), offset); err == nil {
+			t.Errorf("WriteAt en %d debió fallar en un disco de 128 bytes", offset)
+		}
+	}
+
+	// Los límites exactos del disco son válidos
+	if _, err := disk.WriteAt(make([]byte, 64), 64); err != nil {
+		t.Errorf("WriteAt al final del disco: %v", err)
+	}
+	if _, err := disk.ReadAt(make([]byte, 128), 0); err != nil {
+		t.Errorf("ReadAt del disco completo: %v", err)
+	}
+}
+
+func TestDeserializeOutsideDisk(t *testing.T) {
+	path := attachMemoryDisk(t, 100)
+
+	inode := Inode{}
+	if err := inode.Deserialize(path, 50); err == nil {
+		t.Error("Deserialize de un inodo que sale del disco debió fallar")
+	}
+	if err := inode.Serialize(path, 50); err == nil {
+		t.Error("Serialize de un inodo que sale del disco debió fallar")
+	}
+}
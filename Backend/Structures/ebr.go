@@ -1,5 +1,7 @@
 package structures
 
+import "fmt"
+
 type EBR struct {
 	Part_mount [1]byte
 	Part_fit   [2]byte
@@ -8,3 +10,40 @@ type EBR struct {
 	Part_next  int32
 	Part_name  [16]byte
 }
+
+// EBRReserved es el espacio que se reserva para el EBR antes del inicio de su partición lógica
+const EBRReserved = 30
+
+// Serialize escribe el EBR justo antes del inicio de su partición lógica
+func (ebr *EBR) Serialize(path string) error {
+	return writeStruct(path, int64(ebr.Part_start-EBRReserved), ebr)
+}
+
+// Deserialize lee el EBR que inicia en el byte offset
+func (ebr *EBR) Deserialize(path string, offset int64) error {
+	return readStruct(path, offset, ebr)
+}
+
+// GetEBRChain recorre los EBRs de la partición extendida que inicia en start,
+// incluyendo el último EBR vacío que marca el final de la cadena
+func GetEBRChain(path string, start int32) ([]EBR, error) {
+	var chain []EBR
+	offset := start
+
+	for {
+		ebr := EBR{}
+		err := ebr.Deserialize(path, int64(offset))
+		if err != nil {
+			return nil, fmt.Errorf("error al leer el EBR en %d: %v", offset, err)
+		}
+		chain = append(chain, ebr)
+
+		// Un siguiente EBR que no avanza indicaría una cadena corrupta
+		if ebr.Part_next == 0 || ebr.Part_next <= offset {
+			break
+		}
+		offset = ebr.Part_next
+	}
+
+	return chain, nil
+}
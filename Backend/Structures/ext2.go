@@ -1,346 +1,139 @@
 package structures
 
 import (
-	"archivos_pro1/utils"
 	"fmt"
 	"strings"
 	"time"
 )
 
-func (sb *SuperBlock) createFolderInInode(path string, inodeIndex int32, parentsDir []string, destDir string, createParents bool) (bool, error) {
-	// Crear un nuevo inodo
-	inode := &Inode{}
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+// resolveParentFolder recorre parentsDir desde la raíz y devuelve el inodo de la última carpeta.
+// Si createParents está activo, las carpetas que falten se crean a nombre de uid y gid
+func (sb *SuperBlock) resolveParentFolder(path string, parentsDir []string, createParents bool, uid int32, gid int32) (int32, *Inode, error) {
+	folderIndex := int32(0)
+	folder := &Inode{}
+	err := folder.Deserialize(path, sb.InodeOffset(folderIndex))
 	if err != nil {
-		return false, err
+		return -1, nil, err
 	}
-	// Verificar si el inodo es de tipo carpeta
-	if inode.I_type[0] == '1' {
-		return false, nil
-	}
-
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for _, blockIndex := range inode.I_block {
-		// Si el bloque no existe, salir
-		if blockIndex == -1 {
-			break
-		}
 
-		// Crear un nuevo bloque de carpeta
-		block := &FolderBlock{}
+	for i, name := range parentsDir {
+		currentPath := "/" + strings.Join(parentsDir[:i+1], "/")
 
-		// Deserializar el bloque
-		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
+		entry, err := sb.FindEntry(path, folder, name)
 		if err != nil {
-			return false, err
+			return -1, nil, err
 		}
 
-		// Iterar sobre cada contenido del bloque, desde el index 2 porque los primeros dos son . y ..
-		for indexContent := 2; indexContent < len(block.B_content); indexContent++ {
-			// Obtener el contenido del bloque
-			content := block.B_content[indexContent]
-
-			// Sí las carpetas padre no están vacías debereamos buscar la carpeta padre más cercana
-			if len(parentsDir) != 0 {
-				//fmt.Println("---------ESTOY  VISITANDO--------")
-
-				// Si el contenido está vacío, salir
-				if content.B_inodo == -1 {
-					if createParents {
-						_, err := sb.createFolderInInode(path, inodeIndex, []string{}, parentsDir[0], createParents)
-						if err != nil {
-							return false, err
-						}
-
-						err = inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-						if err != nil {
-							return false, err
-						}
-
-						err = block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
-						if err != nil {
-							return false, err
-						}
-
-						content = block.B_content[indexContent]
-
-					} else {
-						return false, fmt.Errorf("the folder %s does not exist", strings.Join(parentsDir, "/"))
-					}
-				}
-
-				// Obtenemos la carpeta padre más cercana
-				parentDir, err := utils.First(parentsDir)
-				if err != nil {
-					return false, err
-				}
-
-				// Convertir B_name a string y eliminar los caracteres nulos
-				contentName := strings.Trim(string(content.B_name[:]), "\x00 ")
-				// Convertir parentDir a string y eliminar los caracteres nulos
-				parentDirName := strings.Trim(parentDir, "\x00 ")
-				if strings.EqualFold(contentName, parentDirName) {
-					_, err := sb.createFolderInInode(path, content.B_inodo, utils.RemoveElement(parentsDir, 0), destDir, createParents)
-					if err != nil {
-						return false, err
-					}
-					return true, nil
-				}
-			} else {
-				//fmt.Println("---------ESTOY  CREANDO--------")
-
-				// Si el apuntador al inodo está ocupado, continuar con el siguiente
-				if content.B_inodo != -1 {
-					continue
-				}
-
-				// Actualizar el contenido del bloque
-				copy(content.B_name[:], destDir)
-				content.B_inodo = sb.S_inodes_count
-
-				// Actualizar el bloque
-				block.B_content[indexContent] = content
-
-				// Serializar el bloque
-				err = block.Serialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
-				if err != nil {
-					return false, err
-				}
-
-				// Crear el inodo de la carpeta
-				folderInode := &Inode{
-					I_uid:   1,
-					I_gid:   1,
-					I_size:  0,
-					I_atime: float32(time.Now().Unix()),
-					I_ctime: float32(time.Now().Unix()),
-					I_mtime: float32(time.Now().Unix()),
-					I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-					I_type:  [1]byte{'0'},
-					I_perm:  [3]byte{'6', '6', '4'},
-				}
-
-				// Serializar el inodo de la carpeta
-				err = folderInode.Serialize(path, int64(sb.S_first_ino))
-				if err != nil {
-					return false, err
-				}
-
-				// Actualizar el bitmap de inodos
-				err = sb.UpdateBitmapInode(path)
-				if err != nil {
-					return false, err
-				}
-
-				// Actualizar el superbloque
-				sb.S_inodes_count++
-				sb.S_free_inodes_count--
-				sb.S_first_ino += sb.S_inode_size
-
-				// Crear el bloque de la carpeta
-				folderBlock := &FolderBlock{
-					B_content: [4]FolderContent{
-						{B_name: [12]byte{'.'}, B_inodo: content.B_inodo},
-						{B_name: [12]byte{'.', '.'}, B_inodo: inodeIndex},
-						{B_name: [12]byte{'-'}, B_inodo: -1},
-						{B_name: [12]byte{'-'}, B_inodo: -1},
-					},
-				}
-
-				// Serializar el bloque de la carpeta
-				err = folderBlock.Serialize(path, int64(sb.S_first_blo))
-				if err != nil {
-					return false, err
-				}
-
-				// Actualizar el bitmap de bloques
-				err = sb.UpdateBitmapBlock(path)
-				if err != nil {
-					return false, err
-				}
-
-				BlocksMap[int(sb.S_blocks_count)] = "Folder Block" // Actualizar el superbloque
-				sb.S_blocks_count++
-				sb.S_free_blocks_count--
-				sb.S_first_blo += sb.S_block_size
+		if entry == nil {
+			if !createParents {
+				return -1, nil, fmt.Errorf("la carpeta %s no existe", currentPath)
+			}
 
-				return true, nil
+			// Crear la carpeta intermedia que falta
+			childIndex, err := sb.MakeFolder(path, folderIndex, folder, name, uid, gid, [3]byte{'6', '6', '4'})
+			if err != nil {
+				return -1, nil, fmt.Errorf("error al crear %s: %w", currentPath, err)
 			}
+			folderIndex = childIndex
+		} else {
+			folderIndex = entry.Inode
 		}
 
+		folder = &Inode{}
+		err = folder.Deserialize(path, sb.InodeOffset(folderIndex))
+		if err != nil {
+			return -1, nil, err
+		}
+		if folder.I_type[0] != '0' {
+			return -1, nil, fmt.Errorf("%s no es una carpeta", currentPath)
+		}
 	}
-	return false, nil
+
+	return folderIndex, folder, nil
 }
 
-// createFolderinode crea una carpeta en un inodo específico
-func (sb *SuperBlock) createFileInInode(path string, inodeIndex int32, parentsDir []string, destFile string, fileSize int, fileContent []string, createParents bool) (bool, error) {
-	// Crear un nuevo inodo
-	inode := &Inode{}
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+// createFolderIn crea la carpeta destDir dentro de parentsDir, asignándola al usuario uid y al grupo gid
+func (sb *SuperBlock) createFolderIn(path string, parentsDir []string, destDir string, createParents bool, uid int32, gid int32) error {
+	parentIndex, parent, err := sb.resolveParentFolder(path, parentsDir, createParents, uid, gid)
 	if err != nil {
-		return false, err
-	}
-	// Verificar si el inodo es de tipo carpeta
-	if inode.I_type[0] == '1' {
-		return false, nil
+		return err
 	}
 
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for _, blockIndex := range inode.I_block {
-		// Si el bloque no existe, salir
-		if blockIndex == -1 {
-			break
-		}
-
-		// Crear un nuevo bloque de carpeta
-		block := &FolderBlock{}
-
-		// Deserializar el bloque
-		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
+	existing, err := sb.FindEntry(path, parent, destDir)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		// Con -p una carpeta existente no es un error
+		child := &Inode{}
+		err := child.Deserialize(path, sb.InodeOffset(existing.Inode))
 		if err != nil {
-			return false, err
+			return err
 		}
+		if createParents && child.I_type[0] == '0' {
+			return nil
+		}
+		return fmt.Errorf("ya existe %s en la carpeta", destDir)
+	}
 
-		// Iterar sobre cada contenido del bloque, desde el index 2 porque los primeros dos son . y ..
-		for indexContent := 2; indexContent < len(block.B_content); indexContent++ {
-			// Obtener el contenido del bloque
-			content := block.B_content[indexContent]
-
-			// Sí las carpetas padre no están vacías debereamos buscar la carpeta padre más cercana
-			if len(parentsDir) != 0 {
-				//fmt.Println("---------ESTOY  VISITANDO--------")
-
-				// Si el contenido está vacío, salir
-				if content.B_inodo == -1 {
-					if createParents {
-						parentsFolders := append([]string(nil), parentsDir[:len(parentsDir)-1]...)
-						destFolder := parentsDir[len(parentsDir)-1]
-						sb.createFolderInInode(path, inodeIndex, parentsFolders, destFolder, true)
-						err = inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-						if err != nil {
-							return false, err
-						}
-
-						err = block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
-						if err != nil {
-							return false, err
-						}
-
-						content = block.B_content[indexContent]
-
-					} else {
-						return false, fmt.Errorf("the folder %s does not exist", strings.Join(parentsDir, "/"))
-					}
-				}
-
-				// Obtenemos la carpeta padre más cercana
-				parentDir, err := utils.First(parentsDir)
-				if err != nil {
-					return false, err
-				}
-
-				// Convertir B_name a string y eliminar los caracteres nulos
-				contentName := strings.Trim(string(content.B_name[:]), "\x00 ")
-				// Convertir parentDir a string y eliminar los caracteres nulos
-				parentDirName := strings.Trim(parentDir, "\x00 ")
-				// Si el nombre del contenido coincide con el nombre de la carpeta padre
-				if strings.EqualFold(contentName, parentDirName) {
-					//fmt.Println("---------ESTOY  ENCONTRANDO--------")
-					// Si son las mismas, entonces entramos al inodo que apunta el bloque
-					_, err := sb.createFileInInode(path, content.B_inodo, utils.RemoveElement(parentsDir, 0), destFile, fileSize, fileContent, createParents)
-					if err != nil {
-						return false, err
-					}
-					return true, nil
-				}
-			} else {
-				//fmt.Println("---------ESTOY  CREANDO--------")
-
-				// Si el apuntador al inodo está ocupado, continuar con el siguiente
-				if content.B_inodo != -1 {
-					continue
-				}
-
-				// Actualizar el contenido del bloque
-				copy(content.B_name[:], []byte(destFile))
-				content.B_inodo = sb.S_inodes_count
-
-				// Actualizar el bloque
-				block.B_content[indexContent] = content
-
-				// Serializar el bloque
-				err = block.Serialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
-				if err != nil {
-					return false, err
-				}
-
-				// Crear el inodo del archivo
-				fileInode := &Inode{
-					I_uid:   1,
-					I_gid:   1,
-					I_size:  int32(fileSize),
-					I_atime: float32(time.Now().Unix()),
-					I_ctime: float32(time.Now().Unix()),
-					I_mtime: float32(time.Now().Unix()),
-					I_block: [15]int32{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-					I_type:  [1]byte{'1'},
-					I_perm:  [3]byte{'6', '6', '4'},
-				}
-
-				// Crear el bloques del archivo
-				for i := 0; i < len(fileContent); i++ {
-					// Actualizamos el inodo del archivo
-					fileInode.I_block[i] = sb.S_blocks_count
-
-					// Creamos el bloque del archivo
-					fileBlock := &FileBlock{
-						B_content: [64]byte{},
-					}
-					// Copiamos el texto de usuarios en el bloque
-					copy(fileBlock.B_content[:], fileContent[i])
+	_, err = sb.MakeFolder(path, parentIndex, parent, destDir, uid, gid, [3]byte{'6', '6', '4'})
+	return err
+}
 
-					// Serializar el bloque de users.txt
-					err = fileBlock.Serialize(path, int64(sb.S_first_blo))
-					if err != nil {
-						return false, err
-					}
+// createFileIn crea el archivo destFile dentro de parentsDir, asignándolo al usuario uid y al grupo gid
+func (sb *SuperBlock) createFileIn(path string, parentsDir []string, destFile string, fileContent string, createParents bool, uid int32, gid int32) error {
+	if len(destFile) > len(FolderContent{}.B_name) {
+		return fmt.Errorf("el nombre %s excede los %d caracteres permitidos", destFile, len(FolderContent{}.B_name))
+	}
 
-					// Actualizar el bitmap de bloques
-					err = sb.UpdateBitmapBlock(path)
-					if err != nil {
-						return false, err
-					}
+	parentIndex, parent, err := sb.resolveParentFolder(path, parentsDir, createParents, uid, gid)
+	if err != nil {
+		return err
+	}
 
-					// Actualizamos el superbloque
-					BlocksMap[int(sb.S_blocks_count)] = "File Block"
-					sb.S_blocks_count++
-					sb.S_free_blocks_count--
-					sb.S_first_blo += sb.S_block_size
-				}
+	existing, err := sb.FindEntry(path, parent, destFile)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("ya existe %s en la carpeta", destFile)
+	}
 
-				// Serializar el inodo de la carpeta
-				err = fileInode.Serialize(path, int64(sb.S_first_ino))
-				if err != nil {
-					return false, err
-				}
+	// Asignar un inodo libre para el archivo
+	fileInodeIndex, err := sb.AllocateInode(path)
+	if err != nil {
+		return err
+	}
 
-				// Actualizar el bitmap de inodos
-				err = sb.UpdateBitmapInode(path)
-				if err != nil {
-					return false, err
-				}
+	// Crear el inodo del archivo
+	now := float32(time.Now().Unix())
+	fileInode := &Inode{
+		I_uid:   uid,
+		I_gid:   gid,
+		I_size:  0,
+		I_atime: now,
+		I_ctime: now,
+		I_mtime: now,
+		I_block: [15]int32{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		I_type:  [1]byte{'1'},
+		I_perm:  [3]byte{'6', '6', '4'},
+	}
 
-				// Actualizar el superbloque
-				sb.S_inodes_count++
-				sb.S_free_inodes_count--
-				sb.S_first_ino += sb.S_inode_size
+	// Escribir el contenido del archivo en sus bloques y serializar el inodo
+	err = sb.WriteFileContent(path, fileInodeIndex, fileInode, fileContent)
+	if err != nil {
+		return err
+	}
 
-				return true, nil
-			}
+	// Enlazar el archivo en la carpeta padre, que crece si sus bloques están llenos;
+	// si no se puede, se liberan el inodo y sus bloques
+	err = sb.AddFolderEntry(path, parentIndex, parent, destFile, fileInodeIndex)
+	if err != nil {
+		if removeErr := sb.RemoveInode(path, fileInodeIndex); removeErr != nil {
+			return fmt.Errorf("%w (y no se pudo liberar el inodo %d: %v)", err, fileInodeIndex, removeErr)
 		}
-
+		return err
 	}
-	return false, nil
+
+	return nil
 }
@@ -0,0 +1,249 @@
+package structures
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Cantidad de bloques de datos que puede direccionar un inodo: 12 directos, indirecto simple, doble y triple
+const maxFileBlocks = 12 + 16 + 16*16 + 16*16*16
+
+// ReadFileContent lee el contenido completo de un archivo, incluyendo los bloques indirectos
+func (sb *SuperBlock) ReadFileContent(path string, inode *Inode) (string, error) {
+	if inode.I_type[0] != '1' {
+		return "", errors.New("el inodo no es un archivo")
+	}
+
+	blocks, err := sb.GetInodeBlocks(path, inode)
+	if err != nil {
+		return "", err
+	}
+
+	content := make([]byte, 0, len(blocks)*int(sb.S_block_size))
+	for _, blockIndex := range blocks {
+		block := &FileBlock{}
+		err := block.Deserialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return "", fmt.Errorf("error al leer el bloque %d: %v", blockIndex, err)
+		}
+		content = append(content, block.B_content[:]...)
+	}
+
+	// El tamaño del inodo indica cuántos bytes del último bloque son válidos
+	if int(inode.I_size) >= 0 && int(inode.I_size) < len(content) {
+		content = content[:inode.I_size]
+	}
+
+	return string(content), nil
+}
+
+// WriteFileContent reemplaza el contenido de un archivo reutilizando sus bloques actuales,
+// asignando los que falten y liberando los que sobren (incluyendo los bloques de apuntadores)
+func (sb *SuperBlock) WriteFileContent(path string, inodeIndex int32, inode *Inode, content string) error {
+	blockSize := int(sb.S_block_size)
+	dataCount := (len(content) + blockSize - 1) / blockSize
+	if dataCount > maxFileBlocks {
+		return fmt.Errorf("el contenido excede el tamaño máximo de un archivo (%d bytes)", maxFileBlocks*blockSize)
+	}
+
+	// Bloques actuales que se pueden reutilizar
+	dataPool, err := sb.GetInodeBlocks(path, inode)
+	if err != nil {
+		return err
+	}
+	pointerPool, err := sb.getPointerBlocks(path, inode)
+	if err != nil {
+		return err
+	}
+
+	// Verificar que haya suficientes bloques libres antes de modificar el disco
+	needed := dataCount + pointerBlocksNeeded(dataCount)
+	available := len(dataPool) + len(pointerPool) + int(sb.S_free_blocks_count)
+	if needed > available {
+		return errors.New("no hay suficientes bloques libres para el contenido")
+	}
+
+	// take reutiliza un bloque del pool o asigna uno nuevo
+	take := func(pool *[]int32) (int32, error) {
+		if len(*pool) > 0 {
+			index := (*pool)[0]
+			*pool = (*pool)[1:]
+			return index, nil
+		}
+		return sb.AllocateBlock(path)
+	}
+
+	// Escribir los bloques de datos
+	dataBlocks := make([]int32, 0, dataCount)
+	for i := 0; i < dataCount; i++ {
+		blockIndex, err := take(&dataPool)
+		if err != nil {
+			return err
+		}
+
+		end := (i + 1) * blockSize
+		if end > len(content) {
+			end = len(content)
+		}
+		fileBlock := &FileBlock{}
+		copy(fileBlock.B_content[:], content[i*blockSize:end])
+
+		err = fileBlock.Serialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
+		dataBlocks = append(dataBlocks, blockIndex)
+	}
+
+	// Asignar los apuntadores directos
+	for i := range inode.I_block {
+		inode.I_block[i] = -1
+	}
+	for i := 0; i < len(dataBlocks) && i < 12; i++ {
+		inode.I_block[i] = dataBlocks[i]
+	}
+
+	// Construir los bloques de apuntadores indirectos que hagan falta
+	remaining := dataBlocks
+	if len(remaining) > 12 {
+		remaining = remaining[12:]
+	} else {
+		remaining = nil
+	}
+	for level := 1; level <= 3 && len(remaining) > 0; level++ {
+		pointer, used, err := sb.buildPointerBlock(path, remaining, level, func() (int32, error) {
+			return take(&pointerPool)
+		})
+		if err != nil {
+			return err
+		}
+		inode.I_block[11+level] = pointer
+		remaining = remaining[used:]
+	}
+
+	// Liberar los bloques que ya no se utilizan
+	for _, blockIndex := range append(dataPool, pointerPool...) {
+		err := sb.FreeBlock(path, blockIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Actualizar el inodo
+	inode.I_size = int32(len(content))
+	inode.I_mtime = float32(time.Now().Unix())
+
+	return inode.Serialize(path, sb.InodeOffset(inodeIndex))
+}
+
+// buildPointerBlock crea un bloque de apuntadores del nivel indicado con los bloques de datos
+// y devuelve su índice junto con la cantidad de bloques de datos que direcciona
+func (sb *SuperBlock) buildPointerBlock(path string, dataBlocks []int32, level int, take func() (int32, error)) (int32, int, error) {
+	pointerIndex, err := take()
+	if err != nil {
+		return -1, 0, err
+	}
+
+	pointerBlock := &PointerBlock{}
+	for i := range pointerBlock.P_pointers {
+		pointerBlock.P_pointers[i] = -1
+	}
+
+	used := 0
+	for i := 0; i < len(pointerBlock.P_pointers) && used < len(dataBlocks); i++ {
+		if level == 1 {
+			pointerBlock.P_pointers[i] = dataBlocks[used]
+			used++
+			continue
+		}
+		child, childUsed, err := sb.buildPointerBlock(path, dataBlocks[used:], level-1, take)
+		if err != nil {
+			return -1, 0, err
+		}
+		pointerBlock.P_pointers[i] = child
+		used += childUsed
+	}
+
+	err = pointerBlock.Serialize(path, sb.BlockOffset(pointerIndex))
+	if err != nil {
+		return -1, 0, err
+	}
+
+	return pointerIndex, used, nil
+}
+
+// getPointerBlocks devuelve los bloques de apuntadores (no de datos) que utiliza el inodo
+func (sb *SuperBlock) getPointerBlocks(path string, inode *Inode) ([]int32, error) {
+	var pointers []int32
+	for level := 1; level <= 3; level++ {
+		pointer := inode.I_block[11+level]
+		if pointer == -1 {
+			continue
+		}
+		nested, err := sb.collectPointerBlocks(path, pointer, level)
+		if err != nil {
+			return nil, err
+		}
+		pointers = append(pointers, nested...)
+	}
+	return pointers, nil
+}
+
+// collectPointerBlocks devuelve el bloque de apuntadores indicado y los bloques de apuntadores que contiene
+func (sb *SuperBlock) collectPointerBlocks(path string, pointer int32, level int) ([]int32, error) {
+	pointers := []int32{pointer}
+	if level == 1 {
+		return pointers, nil
+	}
+
+	pointerBlock := &PointerBlock{}
+	err := pointerBlock.Deserialize(path, sb.BlockOffset(pointer))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range pointerBlock.P_pointers {
+		if child == -1 {
+			continue
+		}
+		nested, err := sb.collectPointerBlocks(path, child, level-1)
+		if err != nil {
+			return nil, err
+		}
+		pointers = append(pointers, nested...)
+	}
+
+	return pointers, nil
+}
+
+// pointerBlocksNeeded calcula cuántos bloques de apuntadores requiere un archivo de dataCount bloques
+func pointerBlocksNeeded(dataCount int) int {
+	remaining := dataCount - 12
+	if remaining <= 0 {
+		return 0
+	}
+
+	count := 0
+	// Indirecto simple
+	count++
+	remaining -= 16
+	if remaining <= 0 {
+		return count
+	}
+
+	// Indirecto doble: un bloque raíz y uno por cada 16 bloques de datos
+	double := remaining
+	if double > 16*16 {
+		double = 16 * 16
+	}
+	count += 1 + (double+15)/16
+	remaining -= double
+	if remaining <= 0 {
+		return count
+	}
+
+	// Indirecto triple: un bloque raíz, uno por cada 256 y uno por cada 16 bloques de datos
+	count += 1 + (remaining+255)/256 + (remaining+15)/16
+	return count
+}
@@ -1,11 +1,6 @@
 package structures
 
-import (
-	"bytes"
-	"encoding/binary"
-	"fmt"
-	"os"
-)
+import "fmt"
 
 type FolderBlock struct {
 	B_content [4]FolderContent // 4 * 16 = 64 bytes
@@ -18,64 +13,14 @@ type FolderContent struct {
 	// Total: 16 bytes
 }
 
-// Serialize escribe la estructura FolderBlock en un archivo binario en la posición especificada
+// Serialize escribe la estructura FolderBlock en el disco en la posición especificada
 func (fb *FolderBlock) Serialize(path string, offset int64) error {
-	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Mover el puntero del archivo a la posición especificada
-	_, err = file.Seek(offset, 0)
-	if err != nil {
-		return err
-	}
-
-	// Serializar la estructura FolderBlock directamente en el archivo
-	err = binary.Write(file, binary.LittleEndian, fb)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return writeStruct(path, offset, fb)
 }
 
-// Deserialize lee la estructura FolderBlock desde un archivo binario en la posición especificada
+// Deserialize lee la estructura FolderBlock desde el disco en la posición especificada
 func (fb *FolderBlock) Deserialize(path string, offset int64) error {
-	file, err := os.Open(path)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Mover el puntero del archivo a la posición especificada
-	_, err = file.Seek(offset, 0)
-	if err != nil {
-		return err
-	}
-
-	// Obtener el tamaño de la estructura FolderBlock
-	fbSize := binary.Size(fb)
-	if fbSize <= 0 {
-		return fmt.Errorf("invalid FolderBlock size: %d", fbSize)
-	}
-
-	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura FolderBlock
-	buffer := make([]byte, fbSize)
-	_, err = file.Read(buffer)
-	if err != nil {
-		return err
-	}
-
-	// Deserializar los bytes leídos en la estructura FolderBlock
-	reader := bytes.NewReader(buffer)
-	err = binary.Read(reader, binary.LittleEndian, fb)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return readStruct(path, offset, fb)
 }
 
 // Print imprime los atributos del bloque de carpeta
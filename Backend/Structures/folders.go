@@ -0,0 +1,407 @@
+package structures
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FindEntry busca una entrada por nombre dentro de la carpeta indicada
+func (sb *SuperBlock) FindEntry(path string, folder *Inode, name string) (*FolderEntry, error) {
+	entries, err := sb.ListFolder(path, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		if strings.EqualFold(entries[i].Name, name) {
+			return &entries[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// RenameEntry cambia el nombre de una entrada de la carpeta folderIndex
+func (sb *SuperBlock) RenameEntry(path string, folderIndex int32, folder *Inode, entry *FolderEntry, newName string) error {
+	// . y .. son las entradas de la propia carpeta y de su padre
+	if entry.Name == "." || entry.Name == ".." {
+		return fmt.Errorf("no se puede renombrar la entrada %s", entry.Name)
+	}
+	if newName == "." || newName == ".." {
+		return fmt.Errorf("el nombre %s está reservado", newName)
+	}
+
+	if len(newName) > len(FolderContent{}.B_name) {
+		return fmt.Errorf("el nombre %s excede los %d caracteres permitidos", newName, len(FolderContent{}.B_name))
+	}
+
+	// Verificar que no exista otra entrada con el mismo nombre
+	existing, err := sb.FindEntry(path, folder, newName)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Inode != entry.Inode {
+		return fmt.Errorf("ya existe %s en la carpeta", newName)
+	}
+
+	block := &FolderBlock{}
+	err = block.Deserialize(path, sb.BlockOffset(entry.Block))
+	if err != nil {
+		return err
+	}
+
+	// Reemplazar el nombre completo para no dejar restos del anterior
+	block.B_content[entry.Slot].B_name = [12]byte{}
+	copy(block.B_content[entry.Slot].B_name[:], newName)
+
+	err = block.Serialize(path, sb.BlockOffset(entry.Block))
+	if err != nil {
+		return err
+	}
+
+	// Actualizar la fecha de modificación de la carpeta
+	folder.I_mtime = float32(time.Now().Unix())
+	return folder.Serialize(path, sb.InodeOffset(folderIndex))
+}
+
+// AddFolderEntry agrega una entrada a la carpeta folderIndex; si sus bloques están llenos
+// asigna un nuevo bloque de carpeta y lo enlaza al inodo
+func (sb *SuperBlock) AddFolderEntry(path string, folderIndex int32, folder *Inode, name string, inodeIndex int32) error {
+	if len(name) > len(FolderContent{}.B_name) {
+		return fmt.Errorf("el nombre %s excede los %d caracteres permitidos", name, len(FolderContent{}.B_name))
+	}
+
+	entry := FolderContent{B_inodo: inodeIndex}
+	copy(entry.B_name[:], name)
+
+	blocks, err := sb.GetInodeBlocks(path, folder)
+	if err != nil {
+		return err
+	}
+
+	// Buscar un espacio libre en los bloques actuales
+	written := false
+	for _, blockIndex := range blocks {
+		block := &FolderBlock{}
+		err := block.Deserialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
+
+		for slot := range block.B_content {
+			if block.B_content[slot].B_inodo != -1 {
+				continue
+			}
+			block.B_content[slot] = entry
+			err = block.Serialize(path, sb.BlockOffset(blockIndex))
+			if err != nil {
+				return err
+			}
+			written = true
+			break
+		}
+		if written {
+			break
+		}
+	}
+
+	// Si la carpeta está llena se crea un nuevo bloque de carpeta
+	if !written {
+		blockIndex, err := sb.AllocateBlock(path)
+		if err != nil {
+			return err
+		}
+
+		block := &FolderBlock{
+			B_content: [4]FolderContent{
+				entry,
+				{B_name: [12]byte{'-'}, B_inodo: -1},
+				{B_name: [12]byte{'-'}, B_inodo: -1},
+				{B_name: [12]byte{'-'}, B_inodo: -1},
+			},
+		}
+		err = block.Serialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
+
+		err = sb.appendInodeBlock(path, folder, blockIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Actualizar la fecha de modificación de la carpeta
+	folder.I_mtime = float32(time.Now().Unix())
+	return folder.Serialize(path, sb.InodeOffset(folderIndex))
+}
+
+// RemoveFolderEntry libera la entrada de la carpeta folderIndex sin tocar el inodo al que apunta
+func (sb *SuperBlock) RemoveFolderEntry(path string, folderIndex int32, folder *Inode, entry *FolderEntry) error {
+	block := &FolderBlock{}
+	err := block.Deserialize(path, sb.BlockOffset(entry.Block))
+	if err != nil {
+		return err
+	}
+
+	block.B_content[entry.Slot] = FolderContent{B_name: [12]byte{'-'}, B_inodo: -1}
+
+	err = block.Serialize(path, sb.BlockOffset(entry.Block))
+	if err != nil {
+		return err
+	}
+
+	// Actualizar la fecha de modificación de la carpeta
+	folder.I_mtime = float32(time.Now().Unix())
+	return folder.Serialize(path, sb.InodeOffset(folderIndex))
+}
+
+// SetParentEntry actualiza la entrada .. de la carpeta para que apunte a parentIndex
+func (sb *SuperBlock) SetParentEntry(path string, folder *Inode, parentIndex int32) error {
+	blocks, err := sb.GetInodeBlocks(path, folder)
+	if err != nil {
+		return err
+	}
+
+	for _, blockIndex := range blocks {
+		block := &FolderBlock{}
+		err := block.Deserialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
+
+		for slot := range block.B_content {
+			name := strings.Trim(string(block.B_content[slot].B_name[:]), "\x00 ")
+			if block.B_content[slot].B_inodo == -1 || name != ".." {
+				continue
+			}
+			block.B_content[slot].B_inodo = parentIndex
+			return block.Serialize(path, sb.BlockOffset(blockIndex))
+		}
+	}
+
+	return fmt.Errorf("la carpeta no tiene entrada ..")
+}
+
+// appendInodeBlock enlaza un bloque al inodo en el primer apuntador directo libre
+// o, si no hay, en el bloque de apuntadores indirecto simple
+func (sb *SuperBlock) appendInodeBlock(path string, inode *Inode, blockIndex int32) error {
+	for i := 0; i < 12; i++ {
+		if inode.I_block[i] == -1 {
+			inode.I_block[i] = blockIndex
+			return nil
+		}
+	}
+
+	pointerBlock := &PointerBlock{}
+	if inode.I_block[12] == -1 {
+		pointerIndex, err := sb.AllocateBlock(path)
+		if err != nil {
+			return err
+		}
+		for i := range pointerBlock.P_pointers {
+			pointerBlock.P_pointers[i] = -1
+		}
+		inode.I_block[12] = pointerIndex
+	} else {
+		err := pointerBlock.Deserialize(path, sb.BlockOffset(inode.I_block[12]))
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range pointerBlock.P_pointers {
+		if pointerBlock.P_pointers[i] == -1 {
+			pointerBlock.P_pointers[i] = blockIndex
+			return pointerBlock.Serialize(path, sb.BlockOffset(inode.I_block[12]))
+		}
+	}
+
+	return fmt.Errorf("la carpeta no admite más bloques")
+}
+
+// CopyInode copia recursivamente el inodo src dentro de la carpeta destIndex con el nombre indicado.
+// Las copias pertenecen a (uid, gid); los archivos y carpetas sin permiso de lectura se omiten
+// y se devuelven sus rutas
+func (sb *SuperBlock) CopyInode(path string, src *Inode, srcPath string, destIndex int32, dest *Inode, name string, uid int32, gid int32) ([]string, error) {
+	if !src.HasPermission(uid, gid, 4) {
+		return []string{srcPath}, nil
+	}
+
+	// Crear el nuevo inodo con los mismos permisos y tipo
+	copyIndex, err := sb.AllocateInode(path)
+	if err != nil {
+		return nil, err
+	}
+
+	now := float32(time.Now().Unix())
+	copyInode := &Inode{
+		I_uid:   uid,
+		I_gid:   gid,
+		I_size:  0,
+		I_atime: now,
+		I_ctime: now,
+		I_mtime: now,
+		I_block: [15]int32{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		I_type:  src.I_type,
+		I_perm:  src.I_perm,
+	}
+
+	var skipped []string
+	if src.I_type[0] == '1' {
+		// Copiar el contenido del archivo en bloques nuevos
+		content, err := sb.ReadFileContent(path, src)
+		if err != nil {
+			return nil, err
+		}
+		err = sb.WriteFileContent(path, copyIndex, copyInode, content)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Crear el bloque inicial de la carpeta con . y ..
+		blockIndex, err := sb.newFolderBlock(path, copyIndex, destIndex)
+		if err != nil {
+			return nil, err
+		}
+		copyInode.I_block[0] = blockIndex
+		err = copyInode.Serialize(path, sb.InodeOffset(copyIndex))
+		if err != nil {
+			return nil, err
+		}
+
+		// Copiar cada entrada de la carpeta
+		entries, err := sb.ListFolder(path, src)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			child := &Inode{}
+			err := child.Deserialize(path, sb.InodeOffset(entry.Inode))
+			if err != nil {
+				return nil, err
+			}
+			childSkipped, err := sb.CopyInode(path, child, strings.TrimSuffix(srcPath, "/")+"/"+entry.Name, copyIndex, copyInode, entry.Name, uid, gid)
+			if err != nil {
+				return nil, err
+			}
+			skipped = append(skipped, childSkipped...)
+		}
+	}
+
+	// Enlazar la copia en la carpeta destino
+	err = sb.AddFolderEntry(path, destIndex, dest, name, copyIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return skipped, nil
+}
+
+// newFolderBlock asigna el bloque inicial de una carpeta con las entradas . y ..
+func (sb *SuperBlock) newFolderBlock(path string, folderIndex int32, parentIndex int32) (int32, error) {
+	blockIndex, err := sb.AllocateBlock(path)
+	if err != nil {
+		return -1, err
+	}
+
+	block := &FolderBlock{
+		B_content: [4]FolderContent{
+			{B_name: [12]byte{'.'}, B_inodo: folderIndex},
+			{B_name: [12]byte{'.', '.'}, B_inodo: parentIndex},
+			{B_name: [12]byte{'-'}, B_inodo: -1},
+			{B_name: [12]byte{'-'}, B_inodo: -1},
+		},
+	}
+	err = block.Serialize(path, sb.BlockOffset(blockIndex))
+	if err != nil {
+		return -1, err
+	}
+
+	return blockIndex, nil
+}
+
+// MakeFolder crea una carpeta vacía llamada name dentro de parentIndex, con el propietario
+// y los permisos indicados, y devuelve el índice de su inodo
+func (sb *SuperBlock) MakeFolder(path string, parentIndex int32, parent *Inode, name string, uid int32, gid int32, perm [3]byte) (int32, error) {
+	if len(name) > len(FolderContent{}.B_name) {
+		return -1, fmt.Errorf("el nombre %s excede los %d caracteres permitidos", name, len(FolderContent{}.B_name))
+	}
+
+	folderIndex, err := sb.AllocateInode(path)
+	if err != nil {
+		return -1, err
+	}
+
+	blockIndex, err := sb.newFolderBlock(path, folderIndex, parentIndex)
+	if err != nil {
+		return -1, err
+	}
+
+	now := float32(time.Now().Unix())
+	folder := &Inode{
+		I_uid:   uid,
+		I_gid:   gid,
+		I_size:  0,
+		I_atime: now,
+		I_ctime: now,
+		I_mtime: now,
+		I_block: [15]int32{blockIndex, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		I_type:  [1]byte{'0'},
+		I_perm:  perm,
+	}
+	err = folder.Serialize(path, sb.InodeOffset(folderIndex))
+	if err != nil {
+		return -1, err
+	}
+
+	err = sb.AddFolderEntry(path, parentIndex, parent, name, folderIndex)
+	if err != nil {
+		return -1, err
+	}
+
+	return folderIndex, nil
+}
+
+// RemoveInode libera recursivamente el inodo indicado junto con su contenido y todos sus bloques.
+// No modifica la entrada que apunta a él en la carpeta padre
+func (sb *SuperBlock) RemoveInode(path string, index int32) error {
+	inode := &Inode{}
+	err := inode.Deserialize(path, sb.InodeOffset(index))
+	if err != nil {
+		return err
+	}
+
+	// Eliminar primero el contenido de las carpetas
+	if inode.I_type[0] == '0' {
+		entries, err := sb.ListFolder(path, inode)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			err := sb.RemoveInode(path, entry.Inode)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Liberar los bloques de datos y de apuntadores
+	dataBlocks, err := sb.GetInodeBlocks(path, inode)
+	if err != nil {
+		return err
+	}
+	pointerBlocks, err := sb.getPointerBlocks(path, inode)
+	if err != nil {
+		return err
+	}
+	for _, blockIndex := range append(dataBlocks, pointerBlocks...) {
+		err := sb.FreeBlock(path, blockIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	return sb.FreeInode(path, index)
+}
@@ -1,10 +1,8 @@
 package structures
 
 import (
-	"bytes"
-	"encoding/binary"
 	"fmt"
-	"os"
+	"strings"
 	"time"
 )
 
@@ -21,64 +19,54 @@ type Inode struct {
 	// Total: 88 bytes
 }
 
-// Serialize escribe la estructura Inode en un archivo binario en la posición especificada
+// Serialize escribe la estructura Inode en el disco en la posición especificada
 func (inode *Inode) Serialize(path string, offset int64) error {
-	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Mover el puntero del archivo a la posición especificada
-	_, err = file.Seek(offset, 0)
-	if err != nil {
-		return err
-	}
-
-	// Serializar la estructura Inode directamente en el archivo
-	err = binary.Write(file, binary.LittleEndian, inode)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return writeStruct(path, offset, inode)
 }
 
-// Deserialize lee la estructura Inode desde un archivo binario en la posición especificada
+// Deserialize lee la estructura Inode desde el disco en la posición especificada
 func (inode *Inode) Deserialize(path string, offset int64) error {
-	file, err := os.Open(path)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+	return readStruct(path, offset, inode)
+}
 
-	// Mover el puntero del archivo a la posición especificada
-	_, err = file.Seek(offset, 0)
-	if err != nil {
-		return err
+// HasPermission verifica si el usuario (uid, gid) tiene el permiso indicado sobre el inodo
+// perm es 4 para lectura, 2 para escritura y 1 para ejecución. El usuario root (UID 1) siempre tiene acceso
+func (inode *Inode) HasPermission(uid int32, gid int32, perm byte) bool {
+	if uid == 1 {
+		return true
 	}
 
-	// Obtener el tamaño de la estructura Inode
-	inodeSize := binary.Size(inode)
-	if inodeSize <= 0 {
-		return fmt.Errorf("invalid Inode size: %d", inodeSize)
+	// I_perm guarda los permisos en octal como caracteres: propietario, grupo y otros
+	var digit byte
+	switch {
+	case inode.I_uid == uid:
+		digit = inode.I_perm[0]
+	case inode.I_gid == gid:
+		digit = inode.I_perm[1]
+	default:
+		digit = inode.I_perm[2]
 	}
 
-	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura Inode
-	buffer := make([]byte, inodeSize)
-	_, err = file.Read(buffer)
-	if err != nil {
-		return err
+	if digit < '0' || digit > '7' {
+		return false
 	}
+	return (digit-'0')&perm != 0
+}
 
-	// Deserializar los bytes leídos en la estructura Inode
-	reader := bytes.NewReader(buffer)
-	err = binary.Read(reader, binary.LittleEndian, inode)
-	if err != nil {
-		return err
+// PermissionString convierte I_perm (por ejemplo 664) en su forma rwx (rw-rw-r--)
+func (inode *Inode) PermissionString() string {
+	var perms strings.Builder
+	for _, digit := range inode.I_perm {
+		value := digit - '0'
+		for bit, letter := range []byte{'r', 'w', 'x'} {
+			if value&(4>>bit) != 0 {
+				perms.WriteByte(letter)
+			} else {
+				perms.WriteByte('-')
+			}
+		}
 	}
-
-	return nil
+	return perms.String()
 }
 
 // Print imprime los atributos del inodo
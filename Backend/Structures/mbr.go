@@ -1,11 +1,9 @@
 package structures
 
 import (
-	"bytes"           // Paquete para manipulación de buffers
 	"encoding/binary" // Paquete para codificación y decodificación de datos binarios
 	"errors"
 	"fmt" // Paquete para formateo de E/S
-	"os"  // Paquete para funciones del sistema operativo
 	"strings"
 	"time" // Paquete para manipulación de tiempo
 )
@@ -21,50 +19,12 @@ type MBR struct {
 
 // SerializeMBR escribe la estructura MBR al inicio de un archivo binario
 func (mbr *MBR) Serialize(path string) error {
-	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Serializar la estructura MBR directamente en el archivo
-	err = binary.Write(file, binary.LittleEndian, mbr)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return writeStruct(path, 0, mbr)
 }
 
 // DeserializeMBR lee la estructura MBR desde el inicio de un archivo binario
 func (mbr *MBR) Deserialize(path string) error {
-	file, err := os.Open(path)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Obtener el tamaño de la estructura MBR
-	mbrSize := binary.Size(mbr)
-	if mbrSize <= 0 {
-		return fmt.Errorf("invalid MBR size: %d", mbrSize)
-	}
-
-	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura MBR
-	buffer := make([]byte, mbrSize)
-	_, err = file.Read(buffer)
-	if err != nil {
-		return err
-	}
-
-	// Deserializar los bytes leídos en la estructura MBR
-	reader := bytes.NewReader(buffer)
-	err = binary.Read(reader, binary.LittleEndian, mbr)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return readStruct(path, 0, mbr)
 }
 
 // Método para obtener la primera partición disponible
@@ -0,0 +1,52 @@
+package structures
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// passwordHashPrefix identifica las contraseñas guardadas como hash en users.txt
+const passwordHashPrefix = "sha256$"
+
+// passwordSaltSize es la cantidad de bytes aleatorios de la sal
+const passwordSaltSize = 8
+
+// HashPassword genera el hash con sal de una contraseña con el formato sha256$<sal>$<hash>
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("error al generar la sal de la contraseña: %v", err)
+	}
+
+	saltHex := hex.EncodeToString(salt)
+	return passwordHashPrefix + saltHex + "$" + hashWithSalt(saltHex, password), nil
+}
+
+// IsPasswordHashed indica si la contraseña guardada ya está en formato de hash
+func IsPasswordHashed(stored string) bool {
+	return strings.HasPrefix(stored, passwordHashPrefix)
+}
+
+// CheckPassword compara una contraseña con la guardada en users.txt, que puede
+// estar en texto plano si aún no se migró
+func CheckPassword(stored string, password string) bool {
+	if !IsPasswordHashed(stored) {
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+	}
+
+	salt, hash, ok := strings.Cut(strings.TrimPrefix(stored, passwordHashPrefix), "$")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(hashWithSalt(salt, password))) == 1
+}
+
+// hashWithSalt calcula el SHA-256 de la sal concatenada con la contraseña
+func hashWithSalt(salt string, password string) string {
+	sum := sha256.Sum256([]byte(salt + password))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,198 @@
+package structures
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FolderEntry representa una entrada de una carpeta (sin contar . y ..)
+type FolderEntry struct {
+	Name  string // Nombre de la entrada
+	Inode int32  // Inodo al que apunta la entrada
+	Block int32  // Bloque de carpeta donde se encuentra la entrada
+	Slot  int    // Posición dentro de B_content
+}
+
+// InodeOffset devuelve el byte donde inicia el inodo con el índice indicado
+func (sb *SuperBlock) InodeOffset(index int32) int64 {
+	return int64(sb.S_inode_start + (index * sb.S_inode_size))
+}
+
+// BlockOffset devuelve el byte donde inicia el bloque con el índice indicado
+func (sb *SuperBlock) BlockOffset(index int32) int64 {
+	return int64(sb.S_block_start + (index * sb.S_block_size))
+}
+
+// GetInodeBlocks devuelve los bloques de datos del inodo en orden, siguiendo los apuntadores indirectos
+func (sb *SuperBlock) GetInodeBlocks(path string, inode *Inode) ([]int32, error) {
+	var blocks []int32
+
+	// Bloques directos
+	for _, blockIndex := range inode.I_block[:12] {
+		if blockIndex != -1 {
+			blocks = append(blocks, blockIndex)
+		}
+	}
+
+	// Bloques indirectos simple, doble y triple
+	for level := 1; level <= 3; level++ {
+		pointer := inode.I_block[11+level]
+		if pointer == -1 {
+			continue
+		}
+		indirect, err := sb.collectIndirectBlocks(path, pointer, level)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, indirect...)
+	}
+
+	return blocks, nil
+}
+
+// BlockTypes determina el tipo de cada bloque en uso a partir de los inodos que lo apuntan,
+// de modo que no depende de lo asignado durante la sesión ni se mezcla entre particiones
+func (sb *SuperBlock) BlockTypes(path string) (map[int32]string, error) {
+	usedInodes, err := sb.UsedInodes(path)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[int32]string)
+	for _, inodeIndex := range usedInodes {
+		inode := &Inode{}
+		err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+		if err != nil {
+			return nil, err
+		}
+
+		dataType := "File Block"
+		if inode.I_type[0] == '0' {
+			dataType = "Folder Block"
+		}
+
+		dataBlocks, err := sb.GetInodeBlocks(path, inode)
+		if err != nil {
+			return nil, err
+		}
+		for _, blockIndex := range dataBlocks {
+			types[blockIndex] = dataType
+		}
+
+		pointerBlocks, err := sb.getPointerBlocks(path, inode)
+		if err != nil {
+			return nil, err
+		}
+		for _, blockIndex := range pointerBlocks {
+			types[blockIndex] = "Pointer Block"
+		}
+	}
+
+	return types, nil
+}
+
+// collectIndirectBlocks recorre un bloque de apuntadores del nivel indicado y devuelve los bloques de datos
+func (sb *SuperBlock) collectIndirectBlocks(path string, pointer int32, level int) ([]int32, error) {
+	pointerBlock := &PointerBlock{}
+	err := pointerBlock.Deserialize(path, sb.BlockOffset(pointer))
+	if err != nil {
+		return nil, fmt.Errorf("error al leer el bloque de apuntadores %d: %v", pointer, err)
+	}
+
+	var blocks []int32
+	for _, blockIndex := range pointerBlock.P_pointers {
+		if blockIndex == -1 {
+			continue
+		}
+		if level == 1 {
+			blocks = append(blocks, blockIndex)
+			continue
+		}
+		nested, err := sb.collectIndirectBlocks(path, blockIndex, level-1)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, nested...)
+	}
+
+	return blocks, nil
+}
+
+// ListFolder devuelve las entradas ocupadas de una carpeta, sin incluir . y ..
+func (sb *SuperBlock) ListFolder(path string, inode *Inode) ([]FolderEntry, error) {
+	if inode.I_type[0] != '0' {
+		return nil, fmt.Errorf("el inodo no es una carpeta")
+	}
+
+	blocks, err := sb.GetInodeBlocks(path, inode)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []FolderEntry
+	for _, blockIndex := range blocks {
+		block := &FolderBlock{}
+		err := block.Deserialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return nil, err
+		}
+
+		for slot, content := range block.B_content {
+			if content.B_inodo == -1 {
+				continue
+			}
+			name := strings.Trim(string(content.B_name[:]), "\x00 ")
+			if name == "." || name == ".." {
+				continue
+			}
+			entries = append(entries, FolderEntry{Name: name, Inode: content.B_inodo, Block: blockIndex, Slot: slot})
+		}
+	}
+
+	return entries, nil
+}
+
+// FindInode busca el inodo que corresponde a una ruta absoluta dentro de la partición
+func (sb *SuperBlock) FindInode(path string, filePath string) (int32, *Inode, error) {
+	inodeIndex := int32(0)
+	inode := &Inode{}
+	err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return -1, nil, err
+	}
+
+	for _, name := range strings.Split(filePath, "/") {
+		if name == "" {
+			continue
+		}
+
+		if inode.I_type[0] != '0' {
+			return -1, nil, fmt.Errorf("la ruta %s no existe", filePath)
+		}
+
+		entries, err := sb.ListFolder(path, inode)
+		if err != nil {
+			return -1, nil, err
+		}
+
+		found := false
+		for _, entry := range entries {
+			if strings.EqualFold(entry.Name, name) {
+				inodeIndex = entry.Inode
+				found = true
+				break
+			}
+		}
+		if !found {
+			return -1, nil, fmt.Errorf("la ruta %s no existe", filePath)
+		}
+
+		inode = &Inode{}
+		err = inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+		if err != nil {
+			return -1, nil, err
+		}
+	}
+
+	return inodeIndex, inode, nil
+}
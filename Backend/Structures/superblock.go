@@ -1,20 +1,12 @@
 package structures
 
 import (
-	"bytes"
-	"encoding/binary"
-	"errors"
+	"archivos_pro1/utils"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 )
 
-// Ahora mejor crear un mapa dentro de otro mapa, donde el primer mapa sea del id de la particion y el segundo mapa sea el de los grupos
-
-var Groups = make(map[string]string)
-var BlocksMap = make(map[int]string)
-
 type SuperBlock struct {
 	S_filesystem_type   int32
 	S_inodes_count      int32
@@ -36,69 +28,29 @@ type SuperBlock struct {
 	// Total: 68 bytes
 }
 
-// Serialize escribe la estructura SuperBlock en un archivo binario en la posición especificada
+// Serialize escribe la estructura SuperBlock en el disco en la posición especificada
 func (sb *SuperBlock) Serialize(path string, offset int64) error {
-	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Mover el puntero del archivo a la posición especificada
-	_, err = file.Seek(offset, 0)
-	if err != nil {
-		return err
-	}
-
-	// Serializar la estructura SuperBlock directamente en el archivo
-	err = binary.Write(file, binary.LittleEndian, sb)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return writeStruct(path, offset, sb)
 }
 
-// Deserialize lee la estructura SuperBlock desde un archivo binario en la posición especificada
+// Deserialize lee la estructura SuperBlock desde el disco en la posición especificada
 func (sb *SuperBlock) Deserialize(path string, offset int64) error {
-	file, err := os.Open(path)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Mover el puntero del archivo a la posición especificada
-	_, err = file.Seek(offset, 0)
-	if err != nil {
-		return err
-	}
-
-	// Obtener el tamaño de la estructura SuperBlock
-	sbSize := binary.Size(sb)
-	if sbSize <= 0 {
-		return fmt.Errorf("invalid SuperBlock size: %d", sbSize)
-	}
+	return readStruct(path, offset, sb)
+}
 
-	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura SuperBlock
-	buffer := make([]byte, sbSize)
-	_, err = file.Read(buffer)
+// Crear users.txt
+func (sb *SuperBlock) CreateUsersFile(path string) error {
+	// ----------- Creamos / -----------
+	// Asignar el inodo y el bloque de la carpeta raíz (los primeros libres en los bitmaps)
+	rootInodeIndex, err := sb.AllocateInode(path)
 	if err != nil {
 		return err
 	}
-
-	// Deserializar los bytes leídos en la estructura SuperBlock
-	reader := bytes.NewReader(buffer)
-	err = binary.Read(reader, binary.LittleEndian, sb)
+	rootBlockIndex, err := sb.AllocateBlock(path)
 	if err != nil {
 		return err
 	}
 
-	return nil
-}
-
-// Crear users.txt
-func (sb *SuperBlock) CreateUsersFile(path string) error {
-	// ----------- Creamos / -----------
 	// Creamos el inodo raíz
 	rootInode := &Inode{
 		I_uid:   1,
@@ -107,93 +59,45 @@ func (sb *SuperBlock) CreateUsersFile(path string) error {
 		I_atime: float32(time.Now().Unix()),
 		I_ctime: float32(time.Now().Unix()),
 		I_mtime: float32(time.Now().Unix()),
-		I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		I_block: [15]int32{rootBlockIndex, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
 		I_type:  [1]byte{'0'},
 		I_perm:  [3]byte{'7', '7', '7'},
 	}
 
 	// Serializar el inodo raíz
-	err := rootInode.Serialize(path, int64(sb.S_first_ino))
-	if err != nil {
-		return err
-	}
-
-	// Actualizar el bitmap de inodos
-	err = sb.UpdateBitmapInode(path)
+	err = rootInode.Serialize(path, sb.InodeOffset(rootInodeIndex))
 	if err != nil {
 		return err
 	}
 
-	// Actualizar el superbloque
-	sb.S_inodes_count++
-	sb.S_free_inodes_count--
-	sb.S_first_ino += sb.S_inode_size
-
 	// Creamos el bloque del Inodo Raíz
 	rootBlock := &FolderBlock{
 		B_content: [4]FolderContent{
-			{B_name: [12]byte{'.'}, B_inodo: 0},
-			{B_name: [12]byte{'.', '.'}, B_inodo: 0},
+			{B_name: [12]byte{'.'}, B_inodo: rootInodeIndex},
+			{B_name: [12]byte{'.', '.'}, B_inodo: rootInodeIndex},
 			{B_name: [12]byte{'-'}, B_inodo: -1},
 			{B_name: [12]byte{'-'}, B_inodo: -1},
 		},
 	}
 
-	// Serializar el bloque de carpeta raíz
-	err = rootBlock.Serialize(path, int64(sb.S_first_blo))
-	if err != nil {
-		return err
-	}
-
-	// Actualizar el bitmap de bloques
-	err = sb.UpdateBitmapBlock(path)
-	if err != nil {
-		return err
-	}
-
-	// Actualizar el superbloque
-	BlocksMap[int(sb.S_blocks_count)] = "Folder Block"
-	sb.S_blocks_count++
-	sb.S_free_blocks_count--
-	sb.S_first_blo += sb.S_block_size
-
 	// Verificar el inodo raíz
 	fmt.Println("\nInodo Raíz:")
 	rootInode.Print()
 
-	// Verificar el bloque de carpeta raíz
-	fmt.Println("\nBloque de Carpeta Raíz:")
-	rootBlock.Print()
-
 	// ----------- Creamos /users.txt -----------
 	usersText := "1,G,root\n1,U,root,root,123\n"
 
-	// Deserializar el inodo raíz
-	err = rootInode.Deserialize(path, int64(sb.S_inode_start+0)) // 0 porque es el inodo raíz
-	if err != nil {
-		return err
-	}
-
-	// Actualizamos el inodo raíz
-	rootInode.I_atime = float32(time.Now().Unix())
-
-	// Serializar el inodo raíz
-	err = rootInode.Serialize(path, int64(sb.S_inode_start+0)) // 0 porque es el inodo raíz
-	if err != nil {
-		return err
-	}
-
-	// Deserializar el bloque de carpeta raíz
-	err = rootBlock.Deserialize(path, int64(sb.S_block_start+0)) // 0 porque es el bloque de carpeta raíz
+	// Asignar el inodo de users.txt
+	usersInodeIndex, err := sb.AllocateInode(path)
 	if err != nil {
 		return err
 	}
 
 	// Actualizamos el bloque de carpeta raíz
-	rootBlock.B_content[2] = FolderContent{B_name: [12]byte{'u', 's', 'e', 'r', 's', '.', 't', 'x', 't'}, B_inodo: sb.S_inodes_count}
+	rootBlock.B_content[2] = FolderContent{B_name: [12]byte{'u', 's', 'e', 'r', 's', '.', 't', 'x', 't'}, B_inodo: usersInodeIndex}
 
 	// Serializar el bloque de carpeta raíz
-	err = rootBlock.Serialize(path, int64(sb.S_block_start+0)) // 0 porque es el bloque de carpeta raíz
+	err = rootBlock.Serialize(path, sb.BlockOffset(rootBlockIndex))
 	if err != nil {
 		return err
 	}
@@ -202,61 +106,21 @@ func (sb *SuperBlock) CreateUsersFile(path string) error {
 	usersInode := &Inode{
 		I_uid:   1,
 		I_gid:   1,
-		I_size:  int32(len(usersText)),
+		I_size:  0,
 		I_atime: float32(time.Now().Unix()),
 		I_ctime: float32(time.Now().Unix()),
 		I_mtime: float32(time.Now().Unix()),
-		I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		I_block: [15]int32{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
 		I_type:  [1]byte{'1'},
-		I_perm:  [3]byte{'7', '7', '7'},
-	}
-
-	// Serializar el inodo users.txt
-	err = usersInode.Serialize(path, int64(sb.S_first_ino))
-	if err != nil {
-		return err
+		I_perm:  [3]byte{'6', '6', '4'},
 	}
 
-	// Actualizar el bitmap de inodos
-	err = sb.UpdateBitmapInode(path)
+	// Escribir el contenido de users.txt y serializar su inodo
+	err = sb.WriteFileContent(path, usersInodeIndex, usersInode, usersText)
 	if err != nil {
 		return err
 	}
 
-	// Actualizamos el superbloque
-	sb.S_inodes_count++
-	sb.S_free_inodes_count--
-	sb.S_first_ino += sb.S_inode_size
-
-	// Creamos el bloque de users.txt
-	usersBlock := &FileBlock{
-		B_content: [64]byte{},
-	}
-	// Copiamos el texto de usuarios en el bloque
-	copy(usersBlock.B_content[:], usersText)
-
-	// Serializar el bloque de users.txt
-	err = usersBlock.Serialize(path, int64(sb.S_first_blo))
-	if err != nil {
-		return err
-	}
-
-	// Actualizar el bitmap de bloques
-	err = sb.UpdateBitmapBlock(path)
-	if err != nil {
-		return err
-	}
-
-	// Actualizamos el superbloque
-	BlocksMap[int(sb.S_blocks_count)] = "File Block"
-	sb.S_blocks_count++
-	sb.S_free_blocks_count--
-	sb.S_first_blo += sb.S_block_size
-
-	// Verificar el inodo raíz
-	fmt.Println("\nInodo Raíz Actualizado:")
-	rootInode.Print()
-
 	// Verificar el bloque de carpeta raíz
 	fmt.Println("\nBloque de Carpeta Raíz Actualizado:")
 	rootBlock.Print()
@@ -265,10 +129,6 @@ func (sb *SuperBlock) CreateUsersFile(path string) error {
 	fmt.Println("\nInodo users.txt:")
 	usersInode.Print()
 
-	// Verificar el bloque de users.txt
-	fmt.Println("\nBloque de users.txt:")
-	usersBlock.Print()
-
 	return nil
 }
 
@@ -297,10 +157,11 @@ func (sb *SuperBlock) Print() {
 	fmt.Printf("Inode Start: %d\n", sb.S_inode_start)
 	fmt.Printf("Block Start: %d\n", sb.S_block_start)
 
-	//Generar el archivo .dot
+	// Generar el archivo .dot de depuración
 	sb.GenerateSBDotFile()
 }
 
+// GenerateSBDotFile guarda el superbloque en formato DOT en la carpeta de artefactos de depuración
 func (sb *SuperBlock) GenerateSBDotFile() {
 	// Convertir el tiempo de montaje a una fecha
 	mountTime := time.Unix(int64(sb.S_mtime), 0)
@@ -344,22 +205,11 @@ func (sb *SuperBlock) GenerateSBDotFile() {
 	dotContent.WriteString("  >];\n")
 	dotContent.WriteString("}\n")
 
-	// Crear el archivo .dot
-	file, err := os.Create("SuperBlock.dot")
+	// Guardar el archivo .dot solo si los artefactos de depuración están activos
+	err := utils.WriteDebugArtifact("SuperBlock.dot", dotContent.String())
 	if err != nil {
 		fmt.Println(err)
-		return
 	}
-	defer file.Close()
-
-	// Escribir el contenido en el archivo
-	_, err = file.WriteString(dotContent.String())
-	if err != nil {
-		fmt.Println("Error escribiendo en el archivo .dot:", err)
-		return
-	}
-
-	fmt.Println("SuperBlock.dot file created")
 }
 
 // Imprimir inodos
@@ -433,702 +283,14 @@ func (sb *SuperBlock) PrintBlocks(path string) error {
 	return nil
 }
 
-// PrintUsersFileContent imprime el contenido de users.txt
-func (sb *SuperBlock) PrintUsersFileContent(path string) (string, error) {
-	rootBlock := &FolderBlock{}
-	// Deserializar el bloque de carpeta raíz
-	err := rootBlock.Deserialize(path, int64(sb.S_block_start+0))
-	if err != nil {
-		return "", fmt.Errorf("error al leer el bloque de carpeta raíz: %v", err)
-	}
-
-	// Buscar el inodo de users.txt
-	var usersInodeIndex int32 = -1
-	for _, content := range rootBlock.B_content {
-		fileName := strings.TrimRight(string(content.B_name[:]), "\x00")
-		if fileName == "users.txt" {
-			usersInodeIndex = content.B_inodo
-			break
-		}
-	}
-
-	if usersInodeIndex == -1 {
-		return "", fmt.Errorf("no se encontró el inodo de users.txt")
-	}
-
-	// Leer el inodo de users.txt
-	usersInode := &Inode{}
-	err = usersInode.Deserialize(path, int64(sb.S_inode_start+(usersInodeIndex*sb.S_inode_size)))
-	if err != nil {
-		return "", fmt.Errorf("error al leer el inodo de users.txt: %v", err)
-	}
-
-	// Leer el contenido del archivo users.txt
-	var usersContent string
-	for i := 0; i < len(usersInode.I_block); i++ {
-
-		if usersInode.I_block[i] == -1 {
-			fmt.Println("NOSEEEEEEEEEEE: ", usersInode.I_block[i])
-			break
-		}
-
-		// Calcular el offset del bloque actual
-
-		blockOffset := sb.S_block_start + (usersInode.I_block[i] * sb.S_block_size)
-
-		// Leer el bloque de datos
-		usersBlock := &FileBlock{}
-		err = usersBlock.Deserialize(path, int64(blockOffset))
-		if err != nil {
-			return "", fmt.Errorf("error al leer el bloque %d de users.txt: %v", i, err)
-		}
-
-		// Convertir el contenido del bloque a string y agregarlo a usersText
-		usersContent += string(usersBlock.B_content[:])
-	}
-
-	if usersInode.I_block[12] != -1 {
-		fmt.Println("APUNTADOR GOKU: ", usersInode.I_block[12])
-		blockIndirect := &PointerBlock{}
-		err = blockIndirect.Deserialize(path, int64(sb.S_block_start+(usersInode.I_block[12]*sb.S_block_size)))
-		if err != nil {
-			fmt.Println("ERROR AL LEER EL BLOQUE INDIRECTO: ", err)
-			return "", fmt.Errorf("error al leer el bloque indirecto de users.txt: %v", err)
-		}
-		fmt.Println("BLOQUE INDIRECTO: ", blockIndirect.P_pointers)
-		for _, blockIndex := range blockIndirect.P_pointers {
-			if blockIndex == -1 {
-				break
-			}
-
-			block := &FileBlock{}
-			err = block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
-			if err != nil {
-				return "", fmt.Errorf("error al leer el bloque %d de users.txt: %v", blockIndex, err)
-			}
-			fmt.Println("BLOQUE: ", blockIndex, " CONTENIDO: ", string(block.B_content[:]))
-			usersContent += string(block.B_content[:])
-		}
-
-	}
-
-	// Agregar los grupos al mapa
-	lines := strings.Split(usersContent, "\n")
-	for _, line := range lines {
-		//fmt.Println("Procesando línea:", line)
-		parts := strings.Split(line, ",")
-
-		// Verificar que la línea tenga al menos 3 partes (ya que los grupos tienen solo 3 partes)
-		if len(parts) < 3 {
-			//fmt.Println("Línea ignorada, no tiene suficientes partes:", parts)
-			continue
-		}
-
-		// Verificar si la línea es un grupo (segunda parte es "G")
-		if parts[1] == "G" {
-			Groups[parts[2]] = "" // Almacenar el nombre del grupo en el mapa
-
-			//fmt.Printf("Grupo añadido: %s\n", parts[2])
-		} else {
-			//fmt.Println("Línea ignorada, no es un grupo:", parts)
-		}
-	}
-
-	fmt.Println("GRUPOS: ", Groups)
-
-	// Devolver el contenido completo de users.txt
-	return usersContent, nil
+// CreateFolder crea una carpeta en el sistema de archivos, propiedad del usuario uid y del grupo gid
+func (sb *SuperBlock) CreateFolder(path string, parentsDir []string, destDir string, createParents bool, uid int32, gid int32) error {
+	return sb.createFolderIn(path, parentsDir, destDir, createParents, uid, gid)
 }
 
-func AddUserGroups(superblock *SuperBlock, path string, user string) error {
-	user = strings.ReplaceAll(user, "\x00", "")
-	// Deserializar el bloque de carpeta raíz para encontrar el inodo de users.txt
-	rootBlock := &FolderBlock{}
-	// Deserializar el bloque de carpeta raíz
-	err := rootBlock.Deserialize(path, int64(superblock.S_block_start+0))
-	if err != nil {
-		return fmt.Errorf("error al leer el bloque de carpeta raíz: %v", err)
-	}
-
-	// Buscar el inodo asociado a users.txt
-	// Buscar el inodo de users.txt
-	var usersInodeIndex int32 = -1
-	for _, content := range rootBlock.B_content {
-		fileName := strings.TrimRight(string(content.B_name[:]), "\x00")
-		//fmt.Println("AAAAA FILENAME: ", fileName)
-		if fileName == "users.txt" {
-			usersInodeIndex = content.B_inodo
-			break
-		}
-	}
-
-	if usersInodeIndex == -1 {
-		return errors.New("users.txt no encontrado en la carpeta raíz")
-	}
-
-	// Leer el inodo de users.txt
-
-	usersInode := &Inode{}
-	InodoStart := superblock.S_inode_start + usersInodeIndex*superblock.S_inode_size
-	err = usersInode.Deserialize(path, int64(InodoStart))
-	if err != nil {
-		return fmt.Errorf("error al leer el inodo de users.txt: %v", err)
-	}
-	//characters := []rune(user)
-	truthyString := strings.ReplaceAll(user, "\x00", "")
-	fmt.Println("TRUTHY STRING HOY: ", truthyString)
-	// Rellenar los bloques
-	var j int = 0
-	for _, indexBlock := range usersInode.I_block {
-		if indexBlock == -1 {
-			break
-		}
-		block := &FileBlock{}
-		err = block.Deserialize(path, int64(superblock.S_block_start+(indexBlock*superblock.S_block_size)))
-		if err != nil {
-			return fmt.Errorf("error al leer el bloque de users.txt: %v", err)
-		}
-		// Actualizar el bloque con el nuevo contenido
-		if len(truthyString) > 64 {
-			subString := truthyString[:64]
-			copy(block.B_content[:], subString)
-			truthyString = truthyString[64:]
-		} else {
-			for i := 0; i < len(block.B_content); i++ {
-				block.B_content[i] = '\x00'
-			}
-
-			copy(block.B_content[:], truthyString)
-			truthyString = ""
-		}
-		err = block.Serialize(path, int64(superblock.S_block_start+(indexBlock*superblock.S_block_size)))
-		if err != nil {
-			return fmt.Errorf("error al actualizar el bloque de users.txt: %v", err)
-		}
-		j++
-	}
-	var i int = 0
-	fmt.Println("TRUTHY STRING: ", truthyString)
-	for _, indexBlock := range usersInode.I_block {
-		if indexBlock == -1 && len(truthyString) > 0 {
-			if len(truthyString) > 64 {
-				block := &FileBlock{}
-				block.B_content = [64]byte{}
-				subString := string(truthyString[:64])
-				copy(block.B_content[:], subString)
-				usersInode.I_block[i] = superblock.S_blocks_count
-				err = block.Serialize(path, int64(superblock.S_block_start+(superblock.S_blocks_count*superblock.S_block_size)))
-				if err != nil {
-					return fmt.Errorf("error al actualizar el bloque de users.txt: %v", err)
-				}
-				truthyString = string(truthyString[64:])
-				// Actualizar el bitmap de bloques
-				err = superblock.UpdateBitmapBlock(path)
-				if err != nil {
-					return err
-				}
-
-				BlocksMap[int(superblock.S_blocks_count)] = "File Block"
-				superblock.S_blocks_count++
-				superblock.S_free_blocks_count--
-				superblock.S_first_blo += superblock.S_block_size
-			} else {
-				fmt.Println("HOLAAAAAAAAAAA")
-				block := &FileBlock{}
-				block.B_content = [64]byte{}
-				copy(block.B_content[:], truthyString)
-				fmt.Println("CONTENNNNT: ", string(block.B_content[:]))
-				usersInode.I_block[i] = superblock.S_blocks_count
-				fmt.Println("INDEX BLOCK: ", usersInode.I_block[i])
-				err = block.Serialize(path, int64(superblock.S_block_start+(superblock.S_blocks_count*superblock.S_block_size)))
-				if err != nil {
-					return fmt.Errorf("error al actualizar el bloque de users.txt: %v", err)
-				}
-				// Actualizar el bitmap de bloques
-				err = superblock.UpdateBitmapBlock(path)
-				if err != nil {
-					return err
-				}
-
-				BlocksMap[int(superblock.S_blocks_count)] = "File Block"
-				superblock.S_blocks_count++
-				superblock.S_free_blocks_count--
-				superblock.S_first_blo += superblock.S_block_size
-				truthyString = ""
-			}
-		}
-		i++
-	}
-	// AQUI VA LO DE LOS INDIRECTOS
-	/*if len(truthyString) > 0 {
-		var k int = 0
-		for k = 12; k < 15; k++ {
-			fmt.Println("ESTE ES EL TRUTHY STRING: ", truthyString)
-			_, err := superblock.indirectBlock(path, truthyString, usersInode, k)
-			if err != nil {
-				return err
-			}
-			break
-
-		}
-	}*/
-
-	// Actualizar el tamaño del archivo en el inodo
-	usersInode.I_size = int32(len(user))
-	usersInode.I_mtime = float32(time.Now().Unix())
-
-	// Serializar el inodo actualizado
-	err = usersInode.Serialize(path, int64(superblock.S_inode_start+(usersInodeIndex*superblock.S_inode_size)))
-	if err != nil {
-		return fmt.Errorf("error al actualizar el inodo de users.txt: %v", err)
-	}
-
-	fullString, err := superblock.PrintUsersFileContent(path)
-	usersInode.Print()
-	if err != nil {
-		return err
-	}
-	fmt.Println("FULL STRING AHHHH: ", fullString)
-
-	return nil
-}
-
-func (sb *SuperBlock) UpdateUsersFile(path string, target string, words string) error {
-	rootBlock := &FolderBlock{}
-	// Deserializar el bloque de carpeta raíz
-	err := rootBlock.Deserialize(path, int64(sb.S_block_start+0))
-	if err != nil {
-		return fmt.Errorf("error al leer el bloque de carpeta raíz: %v", err)
-	}
-
-	// Buscar el inodo de users.txt
-	var usersInodeIndex int32 = -1
-	for _, content := range rootBlock.B_content {
-		fileName := strings.TrimRight(string(content.B_name[:]), "\x00")
-		if fileName == "users.txt" {
-			usersInodeIndex = content.B_inodo
-			break
-		}
-	}
-
-	if usersInodeIndex == -1 {
-		return fmt.Errorf("no se encontró el inodo de users.txt")
-	}
-
-	// Leer el inodo de users.txt
-	usersInode := &Inode{}
-	err = usersInode.Deserialize(path, int64(sb.S_inode_start+(usersInodeIndex*sb.S_inode_size)))
-	if err != nil {
-		return fmt.Errorf("error al leer el inodo de users.txt: %v", err)
-	}
-
-	// Leer y actualizar el contenido del archivo users.txt
-	var updatedContent strings.Builder
-	found := false
-
-	// Actualizar el contenido del bloque
-	blockContent := strings.Split(string(words), "\n")
-	fmt.Println("PALABRAAAAS: ", words)
-	for _, line := range blockContent {
-		parts := strings.Split(line, ",")
-		fmt.Println("PARTS LENGTH: ", len(parts))
-		if len(parts) > 1 && len(parts) < 4 {
-			fmt.Println("PARTS: ", parts)
-			if parts[2] == target {
-				found = true
-				parts[0] = "0"
-				updatedContent.WriteString(strings.Join(parts, ",") + "\n")
-			}
-
-		} else {
-			// Agregar la línea al contenido actualizado
-			updatedContent.WriteString(line + "\n")
-			fmt.Println("LINEA: ", line)
-		}
-	}
-
-	fmt.Println("Updated Content Bytes: ", len(updatedContent.String()))
-	fmt.Println("Updated Content: ", updatedContent.String())
-	characters := []rune(updatedContent.String())
-	truthyString := updatedContent.String()
-	// Rellenar los bloques
-	for _, indexBlock := range usersInode.I_block {
-		if indexBlock == -1 {
-			break
-		}
-		block := &FileBlock{}
-		err = block.Deserialize(path, int64(sb.S_block_start+(indexBlock*sb.S_block_size)))
-		if err != nil {
-			return fmt.Errorf("error al leer el bloque de users.txt: %v", err)
-		}
-		// Actualizar el bloque con el nuevo contenido
-		if len(truthyString) > 64 {
-			subString := string(characters[:64])
-			copy(block.B_content[:], subString)
-			truthyString = string(characters[64:])
-		} else {
-			copy(block.B_content[:], truthyString)
-
-		}
-		err = block.Serialize(path, int64(sb.S_block_start+(indexBlock*sb.S_block_size)))
-		if err != nil {
-			return fmt.Errorf("error al actualizar el bloque de users.txt: %v", err)
-		}
-	}
-
-	if !found {
-		return fmt.Errorf("no se encontró el usuario/grupo con el id %s", target)
-	}
-
-	// Actualizar la última fecha de modificación del inodo
-	usersInode.I_mtime = float32(time.Now().Unix())
-	err = usersInode.Serialize(path, int64(sb.S_inode_start+(usersInodeIndex*sb.S_inode_size)))
-	if err != nil {
-		return fmt.Errorf("error al actualizar el inodo de users.txt: %v", err)
-	}
-
-	return nil
-}
-
-func (sb *SuperBlock) UpdateUsersFileUsers(path string, target string, words string) error {
-	rootBlock := &FolderBlock{}
-	// Deserializar el bloque de carpeta raíz
-	err := rootBlock.Deserialize(path, int64(sb.S_block_start+0))
-	if err != nil {
-		return fmt.Errorf("error al leer el bloque de carpeta raíz: %v", err)
-	}
-
-	// Buscar el inodo de users.txt
-	var usersInodeIndex int32 = -1
-	for _, content := range rootBlock.B_content {
-		fileName := strings.TrimRight(string(content.B_name[:]), "\x00")
-		if fileName == "users.txt" {
-			usersInodeIndex = content.B_inodo
-			break
-		}
-	}
-
-	if usersInodeIndex == -1 {
-		return fmt.Errorf("no se encontró el inodo de users.txt")
-	}
-
-	// Leer el inodo de users.txt
-	usersInode := &Inode{}
-	err = usersInode.Deserialize(path, int64(sb.S_inode_start+(usersInodeIndex*sb.S_inode_size)))
-	if err != nil {
-		return fmt.Errorf("error al leer el inodo de users.txt: %v", err)
-	}
-
-	// Leer y actualizar el contenido del archivo users.txt
-	var updatedContent strings.Builder
-	found := false
-
-	// Actualizar el contenido del bloque
-	blockContent := strings.Split(string(words), "\n")
-	fmt.Println("PALABRAAAAS: ", words)
-	for _, line := range blockContent {
-		parts := strings.Split(line, ",")
-		fmt.Println("PARTS LENGTH: ", len(parts))
-		if len(parts) > 4 {
-			fmt.Println("PARTS: ", parts)
-			if parts[3] == target {
-				found = true
-				parts[0] = "0" // Marcando como eliminado
-				updatedContent.WriteString(strings.Join(parts, ",") + "\n")
-			} else {
-				updatedContent.WriteString(line + "\n")
-			}
-		} else {
-			// Agregar la línea al contenido actualizado
-			updatedContent.WriteString(line + "\n")
-		}
-	}
-
-	if !found {
-		return fmt.Errorf("no se encontró el usuario/grupo con el id %s", target)
-	}
-
-	// Escribir el contenido actualizado en bloques
-	remainingContent := updatedContent.String()
-	for _, indexBlock := range usersInode.I_block {
-		if indexBlock == -1 || len(remainingContent) == 0 {
-			break
-		}
-		block := &FileBlock{}
-		err = block.Deserialize(path, int64(sb.S_block_start+(indexBlock*sb.S_block_size)))
-		if err != nil {
-			return fmt.Errorf("error al leer el bloque de users.txt: %v", err)
-		}
-
-		blockSize := len(block.B_content)
-		if len(remainingContent) > blockSize {
-			copy(block.B_content[:], remainingContent[:blockSize])
-			remainingContent = remainingContent[blockSize:]
-		} else {
-			copy(block.B_content[:], remainingContent)
-			remainingContent = ""
-		}
-
-		err = block.Serialize(path, int64(sb.S_block_start+(indexBlock*sb.S_block_size)))
-		if err != nil {
-			return fmt.Errorf("error al actualizar el bloque de users.txt: %v", err)
-		}
-	}
-
-	// Actualizar la última fecha de modificación del inodo
-	usersInode.I_mtime = float32(time.Now().Unix())
-	err = usersInode.Serialize(path, int64(sb.S_inode_start+(usersInodeIndex*sb.S_inode_size)))
-	if err != nil {
-		return fmt.Errorf("error al actualizar el inodo de users.txt: %v", err)
-	}
-
-	return nil
-}
-
-func (sb *SuperBlock) UpdateGroupForUser(path string, target string, group string, words string) error {
-	rootBlock := &FolderBlock{}
-	// Deserializar el bloque de carpeta raíz
-	err := rootBlock.Deserialize(path, int64(sb.S_block_start+0))
-	if err != nil {
-		return fmt.Errorf("error al leer el bloque de carpeta raíz: %v", err)
-	}
-
-	// Buscar el inodo de users.txt
-	var usersInodeIndex int32 = -1
-	for _, content := range rootBlock.B_content {
-		fileName := strings.TrimRight(string(content.B_name[:]), "\x00")
-		if fileName == "users.txt" {
-			usersInodeIndex = content.B_inodo
-			break
-		}
-	}
-
-	if usersInodeIndex == -1 {
-		return fmt.Errorf("no se encontró el inodo de users.txt")
-	}
-
-	// Leer el inodo de users.txt
-	usersInode := &Inode{}
-	err = usersInode.Deserialize(path, int64(sb.S_inode_start+(usersInodeIndex*sb.S_inode_size)))
-	if err != nil {
-		return fmt.Errorf("error al leer el inodo de users.txt: %v", err)
-	}
-
-	// Leer y actualizar el contenido del archivo users.txt
-	var updatedContent strings.Builder
-	found := false
-
-	// Actualizar el contenido del bloque
-	blockContent := strings.Split(string(words), "\n")
-	fmt.Println("PALABRAAAAS: ", words)
-	for _, line := range blockContent {
-		parts := strings.Split(line, ",")
-		fmt.Println("PARTS LENGTH: ", len(parts))
-		if len(parts) > 4 {
-			fmt.Println("PARTS: ", parts)
-			if parts[3] == target {
-				found = true
-				parts[2] = group // Marcando como eliminado
-				updatedContent.WriteString(strings.Join(parts, ",") + "\n")
-			} else {
-				updatedContent.WriteString(line + "\n")
-			}
-		} else {
-			// Agregar la línea al contenido actualizado
-			updatedContent.WriteString(line + "\n")
-		}
-	}
-
-	if !found {
-		return fmt.Errorf("no se encontró el usuario/grupo con el id %s", target)
-	}
-
-	// Escribir el contenido actualizado en bloques
-	remainingContent := updatedContent.String()
-	for _, indexBlock := range usersInode.I_block {
-		if indexBlock == -1 || len(remainingContent) == 0 {
-			break
-		}
-		block := &FileBlock{}
-		err = block.Deserialize(path, int64(sb.S_block_start+(indexBlock*sb.S_block_size)))
-		if err != nil {
-			return fmt.Errorf("error al leer el bloque de users.txt: %v", err)
-		}
-
-		blockSize := len(block.B_content)
-		if len(remainingContent) > blockSize {
-			copy(block.B_content[:], remainingContent[:blockSize])
-			remainingContent = remainingContent[blockSize:]
-		} else {
-			copy(block.B_content[:], remainingContent)
-			remainingContent = ""
-		}
-
-		err = block.Serialize(path, int64(sb.S_block_start+(indexBlock*sb.S_block_size)))
-		if err != nil {
-			return fmt.Errorf("error al actualizar el bloque de users.txt: %v", err)
-		}
-	}
-
-	// Actualizar la última fecha de modificación del inodo
-	usersInode.I_mtime = float32(time.Now().Unix())
-	err = usersInode.Serialize(path, int64(sb.S_inode_start+(usersInodeIndex*sb.S_inode_size)))
-	if err != nil {
-		return fmt.Errorf("error al actualizar el inodo de users.txt: %v", err)
-	}
-
-	return nil
-}
-
-/*func (superblock *SuperBlock) indirectBlock(path string, userText string, InodeUser *Inode, counter int) (string, error) {
-	userText = strings.ReplaceAll(userText, "\x00", "")
-	switch counter {
-	case 12:
-		var blockIndirect *PointerBlock
-		if InodeUser.I_block[counter] == -1 {
-			blockIndirect = &PointerBlock{[16]int32{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}}
-			InodeUser.I_block[counter] = superblock.S_blocks_count
-
-			//Serializar el bloque indirecto
-			err := blockIndirect.Serialize(path, int64(superblock.S_first_blo))
-			if err != nil {
-				return "", fmt.Errorf("error al serializar el bloque indirecto: %v", err)
-			}
-
-			superblock.S_blocks_count++
-			superblock.S_free_blocks_count--
-			superblock.S_first_blo += superblock.S_block_size
-
-		}
-
-		blockStarter := superblock.S_block_start + (InodeUser.I_block[counter] * superblock.S_block_size)
-		blockIndirect = &PointerBlock{}
-		err := blockIndirect.Deserialize(path, int64(blockStarter))
-		fmt.Println("BLOQUE INDIRECTO PIOLIN: ", blockIndirect.P_pointers, "PASTEL: ", blockStarter)
-		if err != nil {
-			return "", fmt.Errorf("error al deserializar el bloque indirecto: %v", err)
-		}
-
-		for _, indexBlock := range blockIndirect.P_pointers {
-			if indexBlock == -1 {
-				break
-			}
-			fmt.Println("FUNCION INDIRECT: ", indexBlock)
-			block := &FileBlock{}
-			err = block.Deserialize(path, int64(superblock.S_block_start+(indexBlock*superblock.S_block_size)))
-			if err != nil {
-				return "", fmt.Errorf("error al leer el bloque de users.txt: %v", err)
-			}
-			// Actualizar el bloque con el nuevo contenido
-			if len(userText) > 64 {
-				subString := userText[:64]
-				copy(block.B_content[:], subString)
-				userText = userText[64:]
-			} else {
-				for i := 0; i < len(block.B_content); i++ {
-					block.B_content[i] = '\x00'
-				}
-
-				copy(block.B_content[:], userText)
-				userText = ""
-			}
-			err = block.Serialize(path, int64(superblock.S_block_start+(indexBlock*superblock.S_block_size)))
-			if err != nil {
-				return "", fmt.Errorf("error al actualizar el bloque de users.txt: %v", err)
-			}
-		}
-		var i int = 0
-		fmt.Println("TRUTHY STRING: ", userText)
-		for _, indexBlock := range blockIndirect.P_pointers {
-			if indexBlock == -1 && i < 12 && len(userText) > 0 {
-				if len(userText) > 64 {
-					block := &FileBlock{}
-					block.B_content = [64]byte{}
-					subString := string(userText[:64])
-					copy(block.B_content[:], subString)
-					blockIndirect.P_pointers[i] = superblock.S_blocks_count
-					err = block.Serialize(path, int64(superblock.S_first_blo))
-					if err != nil {
-						return "", fmt.Errorf("error al actualizar el bloque de users.txt: %v", err)
-					}
-					userText = string(userText[64:])
-					superblock.S_blocks_count++
-					superblock.S_free_blocks_count--
-					superblock.S_first_blo += superblock.S_block_size
-				} else {
-					fmt.Println("HOLAAAAAAAAAAA")
-					block := &FileBlock{}
-					block.B_content = [64]byte{}
-					copy(block.B_content[:], userText)
-					fmt.Println("CONTENNNNT: ", string(block.B_content[:]))
-					blockIndirect.P_pointers[i] = superblock.S_blocks_count
-					fmt.Println("INDEX BLOCK: ", blockIndirect.P_pointers[i])
-					err = block.Serialize(path, int64(superblock.S_first_blo))
-					if err != nil {
-						return "", fmt.Errorf("error al actualizar el bloque de users.txt: %v", err)
-					}
-					superblock.S_blocks_count++
-					superblock.S_free_blocks_count--
-					superblock.S_first_blo += superblock.S_block_size
-					userText = ""
-				}
-			}
-			fmt.Println("COUNT COUNT: ", superblock.S_blocks_count)
-			i++
-		}
-		fmt.Println("BLOQUE INDIRECTO PIOLIN: ", blockIndirect.P_pointers, userText, "AGUA: ", superblock.S_block_start+(InodeUser.I_block[counter]*superblock.S_block_size))
-		err = blockIndirect.Serialize(path, int64(superblock.S_block_start+(InodeUser.I_block[counter]*superblock.S_block_size)))
-		if err != nil {
-			return "", fmt.Errorf("error al actualizar el bloque de users.txt: %v", err)
-		}
-
-	}
-
-	return "", nil
-}*/
-
-func (sb *SuperBlock) CreateFolder(path string, parentsDir []string, destDir string, createParents bool) error {
-	// Si parentsDir está vacío, solo trabajar con el primer inodo que sería el raíz "/"
-	if len(parentsDir) == 0 {
-		_, err := sb.createFolderInInode(path, 0, parentsDir, destDir, createParents)
-		return err
-	}
-
-	// Iterar sobre cada inodo ya que se necesita buscar el inodo padre
-	for i := int32(0); i < sb.S_inodes_count; i++ {
-		verification, err := sb.createFolderInInode(path, i, parentsDir, destDir, createParents)
-		if err != nil {
-			return err
-		}
-		if verification {
-			break
-		}
-	}
-
-	return nil
-}
-
-// CreateFile crea un archivo en el sistema de archivos
-func (sb *SuperBlock) CreateFile(path string, parentsDir []string, destFile string, size int, cont []string, createParents bool) error {
-
-	// Si parentsDir está vacío, solo trabajar con el primer inodo que sería el raíz "/"
-	if len(parentsDir) == 0 {
-		_, err := sb.createFileInInode(path, 0, parentsDir, destFile, size, cont, createParents)
-		return err
-	}
-
-	// Iterar sobre cada inodo ya que se necesita buscar el inodo padre
-	for i := int32(0); i < sb.S_inodes_count; i++ {
-		create, err := sb.createFileInInode(path, i, parentsDir, destFile, size, cont, createParents)
-		if err != nil {
-			return err
-		}
-		if create {
-			break
-		}
-	}
-
-	return nil
+// CreateFile crea un archivo en el sistema de archivos, propiedad del usuario uid y del grupo gid
+func (sb *SuperBlock) CreateFile(path string, parentsDir []string, destFile string, content string, createParents bool, uid int32, gid int32) error {
+	return sb.createFileIn(path, parentsDir, destFile, content, createParents, uid, gid)
 }
 
 /*func (sb *SuperBlock) DirectoryExists(partitionPath string, dirPath string) bool {
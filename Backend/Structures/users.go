@@ -0,0 +1,336 @@
+package structures
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GroupRecord representa una línea de grupo de users.txt (GID,G,grupo)
+type GroupRecord struct {
+	ID   int32
+	Name string
+}
+
+// UserRecord representa una línea de usuario de users.txt (UID,U,grupo,usuario,contraseña)
+type UserRecord struct {
+	ID       int32
+	Group    string
+	Name     string
+	Password string
+}
+
+// ParseUsersFile separa el contenido de users.txt en grupos y usuarios
+func ParseUsersFile(content string) ([]GroupRecord, []UserRecord) {
+	var groups []GroupRecord
+	var users []UserRecord
+
+	content = strings.ReplaceAll(content, "\x00", "")
+	for _, line := range strings.Split(content, "\n") {
+		parts := strings.Split(strings.TrimSpace(line), ",")
+		if len(parts) < 3 {
+			continue
+		}
+
+		id, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil {
+			continue
+		}
+
+		switch strings.TrimSpace(parts[1]) {
+		case "G":
+			groups = append(groups, GroupRecord{ID: int32(id), Name: strings.TrimSpace(parts[2])})
+		case "U":
+			if len(parts) < 5 {
+				continue
+			}
+			users = append(users, UserRecord{
+				ID:       int32(id),
+				Group:    strings.TrimSpace(parts[2]),
+				Name:     strings.TrimSpace(parts[3]),
+				Password: strings.TrimSpace(parts[4]),
+			})
+		}
+	}
+
+	return groups, users
+}
+
+// FindActiveGroup busca un grupo no eliminado (ID distinto de 0) por nombre
+func FindActiveGroup(groups []GroupRecord, name string) *GroupRecord {
+	for i := range groups {
+		if groups[i].ID != 0 && groups[i].Name == name {
+			return &groups[i]
+		}
+	}
+	return nil
+}
+
+// FindActiveUser busca un usuario no eliminado (ID distinto de 0) por nombre
+func FindActiveUser(users []UserRecord, name string) *UserRecord {
+	for i := range users {
+		if users[i].ID != 0 && users[i].Name == name {
+			return &users[i]
+		}
+	}
+	return nil
+}
+
+// UserNameByID devuelve el nombre del usuario con el UID indicado, o el UID si no existe
+func UserNameByID(users []UserRecord, id int32) string {
+	for _, user := range users {
+		if user.ID == id {
+			return user.Name
+		}
+	}
+	return strconv.Itoa(int(id))
+}
+
+// GroupNameByID devuelve el nombre del grupo con el GID indicado, o el GID si no existe
+func GroupNameByID(groups []GroupRecord, id int32) string {
+	for _, group := range groups {
+		if group.ID == id {
+			return group.Name
+		}
+	}
+	return strconv.Itoa(int(id))
+}
+
+// GetUserIDs obtiene el UID del usuario y el GID de su grupo a partir de users.txt
+func (sb *SuperBlock) GetUserIDs(path string, name string) (int32, int32, error) {
+	content, err := sb.ReadUsersFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return UserIDs(content, name)
+}
+
+// UserIDs obtiene el UID del usuario y el GID de su grupo a partir del contenido de users.txt
+func UserIDs(content string, name string) (int32, int32, error) {
+	groups, users := ParseUsersFile(content)
+
+	user := FindActiveUser(users, name)
+	if user == nil {
+		return 0, 0, fmt.Errorf("el usuario %s no existe", name)
+	}
+
+	group := FindActiveGroup(groups, user.Group)
+	if group == nil {
+		return 0, 0, fmt.Errorf("el grupo %s del usuario %s no existe", user.Group, name)
+	}
+
+	return user.ID, group.ID, nil
+}
+
+// ReadUsersFile lee el contenido completo de users.txt
+func (sb *SuperBlock) ReadUsersFile(path string) (string, error) {
+	_, usersInode, err := sb.FindInode(path, "/users.txt")
+	if err != nil {
+		return "", fmt.Errorf("no se encontró users.txt: %v", err)
+	}
+	return sb.ReadFileContent(path, usersInode)
+}
+
+// WriteUsersFile reemplaza el contenido de users.txt; el superbloque debe serializarse después
+func (sb *SuperBlock) WriteUsersFile(path string, content string) error {
+	usersInodeIndex, usersInode, err := sb.FindInode(path, "/users.txt")
+	if err != nil {
+		return fmt.Errorf("no se encontró users.txt: %v", err)
+	}
+	return sb.WriteFileContent(path, usersInodeIndex, usersInode, content)
+}
+
+// UpdateUsersFile lee users.txt, aplica update sobre su contenido y escribe el resultado;
+// el superbloque debe serializarse después
+func (sb *SuperBlock) UpdateUsersFile(path string, update func(content string) (string, error)) error {
+	content, err := sb.ReadUsersFile(path)
+	if err != nil {
+		return err
+	}
+
+	content, err = update(content)
+	if err != nil {
+		return err
+	}
+
+	return sb.WriteUsersFile(path, content)
+}
+
+// usersFileLines separa users.txt en líneas, descartando caracteres nulos y líneas vacías
+func usersFileLines(content string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\x00", ""), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return lines
+}
+
+// joinUsersFileLines vuelve a unir las líneas de users.txt, terminando cada una con salto de línea
+func joinUsersFileLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// editUsersFileLines aplica edit a los campos de cada línea activa del tipo kind ("G" o "U")
+// y devuelve el contenido resultante junto con la cantidad de líneas modificadas
+func editUsersFileLines(content string, kind string, edit func(parts []string) bool) (string, int) {
+	lines := usersFileLines(content)
+	edited := 0
+
+	for i, line := range lines {
+		parts := strings.Split(line, ",")
+		if len(parts) < 3 || strings.TrimSpace(parts[1]) != kind || strings.TrimSpace(parts[0]) == "0" {
+			continue
+		}
+		for j := range parts {
+			parts[j] = strings.TrimSpace(parts[j])
+		}
+
+		if edit(parts) {
+			lines[i] = strings.Join(parts, ",")
+			edited++
+		}
+	}
+
+	return joinUsersFileLines(lines), edited
+}
+
+// nextUsersFileID devuelve el siguiente ID para una línea del tipo kind; los registros
+// eliminados conservan su lugar para que su ID no se reutilice
+func nextUsersFileID(content string, kind string) int {
+	count := 0
+	for _, line := range usersFileLines(content) {
+		parts := strings.Split(line, ",")
+		if len(parts) >= 2 && strings.TrimSpace(parts[1]) == kind {
+			count++
+		}
+	}
+	return count + 1
+}
+
+// validateUsersFileName verifica que un nombre pueda guardarse como campo de users.txt
+func validateUsersFileName(name string) error {
+	if name == "" {
+		return fmt.Errorf("el nombre no puede estar vacío")
+	}
+	if strings.ContainsAny(name, ",\n") {
+		return fmt.Errorf("el nombre %s no puede contener comas ni saltos de línea", name)
+	}
+	return nil
+}
+
+// AddGroup agrega el grupo name al contenido de users.txt
+func AddGroup(content string, name string) (string, error) {
+	if err := validateUsersFileName(name); err != nil {
+		return "", err
+	}
+
+	groups, _ := ParseUsersFile(content)
+	if FindActiveGroup(groups, name) != nil {
+		return "", fmt.Errorf("el grupo %s ya existe", name)
+	}
+
+	line := strconv.Itoa(nextUsersFileID(content, "G")) + ",G," + name
+	return joinUsersFileLines(append(usersFileLines(content), line)), nil
+}
+
+// AddUser agrega el usuario name del grupo group al contenido de users.txt;
+// password debe venir ya procesada con HashPassword
+func AddUser(content string, group string, name string, password string) (string, error) {
+	if err := validateUsersFileName(name); err != nil {
+		return "", err
+	}
+
+	groups, users := ParseUsersFile(content)
+	if FindActiveGroup(groups, group) == nil {
+		return "", fmt.Errorf("el grupo %s no existe", group)
+	}
+	if FindActiveUser(users, name) != nil {
+		return "", fmt.Errorf("el usuario %s ya existe", name)
+	}
+
+	line := strconv.Itoa(nextUsersFileID(content, "U")) + ",U," + group + "," + name + "," + password
+	return joinUsersFileLines(append(usersFileLines(content), line)), nil
+}
+
+// RemoveGroup marca como eliminados (ID 0) al grupo name y a todos sus usuarios
+func RemoveGroup(content string, name string) (string, error) {
+	content, edited := editUsersFileLines(content, "G", func(parts []string) bool {
+		if parts[2] != name {
+			return false
+		}
+		parts[0] = "0"
+		return true
+	})
+	if edited == 0 {
+		return "", fmt.Errorf("el grupo %s no existe", name)
+	}
+
+	content, _ = editUsersFileLines(content, "U", func(parts []string) bool {
+		if len(parts) < 5 || parts[2] != name {
+			return false
+		}
+		parts[0] = "0"
+		return true
+	})
+
+	return content, nil
+}
+
+// RemoveUser marca como eliminado (ID 0) al usuario name
+func RemoveUser(content string, name string) (string, error) {
+	content, edited := editUsersFileLines(content, "U", func(parts []string) bool {
+		if len(parts) < 5 || parts[3] != name {
+			return false
+		}
+		parts[0] = "0"
+		return true
+	})
+	if edited == 0 {
+		return "", fmt.Errorf("el usuario %s no existe", name)
+	}
+
+	return content, nil
+}
+
+// SetUserGroup cambia el grupo del usuario activo name por group
+func SetUserGroup(content string, name string, group string) (string, error) {
+	groups, _ := ParseUsersFile(content)
+	if FindActiveGroup(groups, group) == nil {
+		return "", fmt.Errorf("el grupo %s no existe", group)
+	}
+
+	content, edited := editUsersFileLines(content, "U", func(parts []string) bool {
+		if len(parts) < 5 || parts[3] != name {
+			return false
+		}
+		parts[2] = group
+		return true
+	})
+	if edited == 0 {
+		return "", fmt.Errorf("el usuario %s no existe", name)
+	}
+
+	return content, nil
+}
+
+// SetUserPassword reemplaza la contraseña del usuario activo name en el contenido de users.txt
+func SetUserPassword(content string, name string, password string) (string, error) {
+	content, edited := editUsersFileLines(content, "U", func(parts []string) bool {
+		if len(parts) < 5 || parts[3] != name {
+			return false
+		}
+		parts[4] = password
+		return true
+	})
+	if edited == 0 {
+		return "", fmt.Errorf("el usuario %s no existe", name)
+	}
+
+	return content, nil
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 )
 
-var GroupsUser = make(map[string]map[string][]string)
 var DirectoriesCreated = make(map[string]map[string][]string)
 
 // Carnet de estudiante
@@ -2,8 +2,13 @@ package main
 
 import (
 	"archivos_pro1/Analyzer"
+	"archivos_pro1/reports"
+	"archivos_pro1/utils"
 	"encoding/json"
+	"flag"
+	"log"
 	"net/http"
+	"strconv"
 )
 
 type CodeRequest struct {
@@ -14,11 +19,16 @@ type CodeResponse struct {
 	Outputs interface{} `json:"output"`
 }
 
-func runCodeHandler(w http.ResponseWriter, r *http.Request) {
-	// Habilitar CORS
+// enableCORS agrega los encabezados CORS para el frontend
+func enableCORS(w http.ResponseWriter, methods string) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Methods", methods)
 	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+}
+
+func runCodeHandler(w http.ResponseWriter, r *http.Request) {
+	// Habilitar CORS
+	enableCORS(w, "POST, OPTIONS")
 
 	if r.Method == http.MethodOptions {
 		w.WriteHeader(http.StatusOK)
@@ -46,7 +56,49 @@ func runCodeHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// listReportsHandler devuelve los reportes generados durante la sesión
+func listReportsHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(w, "GET")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reports.ListReports())
+}
+
+// getReportHandler envía el archivo del reporte con su tipo de contenido
+func getReportHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(w, "GET")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid report id", http.StatusBadRequest)
+		return
+	}
+
+	report, ok := reports.GetReport(id)
+	if !ok {
+		http.Error(w, "report not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", report.ContentType)
+	http.ServeFile(w, r, report.Path)
+}
+
 func main() {
+	reportsRoot := flag.String("reports", "reportes", "carpeta donde se guardan los reportes generados")
+	debugDir := flag.String("debug", "", "carpeta para artefactos de depuración (desactivados si está vacía)")
+	flag.Parse()
+
+	if err := reports.SetOutputRoot(*reportsRoot); err != nil {
+		log.Fatalf("error configurando la carpeta de reportes: %v", err)
+	}
+
+	if err := utils.SetDebugDir(*debugDir); err != nil {
+		log.Fatalf("error configurando la carpeta de depuración: %v", err)
+	}
+
 	http.HandleFunc("/run-code", runCodeHandler)
+	http.HandleFunc("GET /reports", listReportsHandler)
+	http.HandleFunc("GET /reports/{id}", getReportHandler)
 	http.ListenAndServe(":8080", nil)
 }
@@ -0,0 +1,44 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// escapeLabel prepara texto no confiable para insertarlo en un label HTML de Graphviz:
+// escapa los caracteres especiales de HTML, convierte los saltos de línea en <br/>
+// y muestra los bytes no imprimibles en hexadecimal (\xNN)
+func escapeLabel(text string) string {
+	var escaped strings.Builder
+
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			// Byte que no forma un carácter UTF-8 válido
+			fmt.Fprintf(&escaped, "\\x%02X", text[0])
+		case r == '&':
+			escaped.WriteString("&amp;")
+		case r == '<':
+			escaped.WriteString("&lt;")
+		case r == '>':
+			escaped.WriteString("&gt;")
+		case r == '"':
+			escaped.WriteString("&quot;")
+		case r == '\'':
+			escaped.WriteString("&#39;")
+		case r == '\n':
+			escaped.WriteString("<br/>")
+		case r < 0x20 || r == 0x7F:
+			fmt.Fprintf(&escaped, "\\x%02X", r)
+		default:
+			escaped.WriteRune(r)
+		}
+
+		text = text[size:]
+	}
+
+	return escaped.String()
+}
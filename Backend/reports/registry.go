@@ -0,0 +1,118 @@
+package reports
+
+import (
+	"errors"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GeneratedReport describe un reporte generado durante la sesión
+type GeneratedReport struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Created     time.Time `json:"created"`
+	Path        string    `json:"-"`
+}
+
+var (
+	outputRoot       = "reportes" // Carpeta donde se guardan todos los reportes
+	generatedReports []GeneratedReport
+	registryMutex    sync.Mutex
+)
+
+// SetOutputRoot configura la carpeta raíz donde se escriben los reportes
+func SetOutputRoot(root string) error {
+	if root == "" {
+		return errors.New("la carpeta de reportes no puede estar vacía")
+	}
+
+	absolute, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(absolute, os.ModePerm); err != nil {
+		return err
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	outputRoot = absolute
+	return nil
+}
+
+// ResolvePath ubica la ruta pedida por el usuario dentro de la carpeta raíz de reportes,
+// de modo que ningún reporte pueda escribirse fuera de ella
+func ResolvePath(reportPath string) string {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	// path.Clean sobre una ruta absoluta elimina cualquier ".." que intente salir de la raíz
+	cleaned := path.Clean("/" + filepath.ToSlash(reportPath))
+	return filepath.Join(outputRoot, filepath.FromSlash(cleaned))
+}
+
+// ListReports devuelve los reportes generados durante la sesión
+func ListReports() []GeneratedReport {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	reports := make([]GeneratedReport, len(generatedReports))
+	copy(reports, generatedReports)
+	return reports
+}
+
+// GetReport busca un reporte generado por su ID
+func GetReport(id int) (*GeneratedReport, bool) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	for i := range generatedReports {
+		if generatedReports[i].ID == id {
+			report := generatedReports[i]
+			return &report, true
+		}
+	}
+	return nil, false
+}
+
+// recordReport registra el archivo generado; si la ruta ya existía solo se actualiza su fecha
+func recordReport(reportPath string) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	for i := range generatedReports {
+		if generatedReports[i].Path == reportPath {
+			generatedReports[i].Created = time.Now()
+			return
+		}
+	}
+
+	generatedReports = append(generatedReports, GeneratedReport{
+		ID:          len(generatedReports) + 1,
+		Name:        filepath.Base(reportPath),
+		ContentType: contentType(reportPath),
+		Created:     time.Now(),
+		Path:        reportPath,
+	})
+}
+
+// contentType obtiene el tipo de contenido a partir de la extensión del reporte
+func contentType(reportPath string) string {
+	switch strings.ToLower(filepath.Ext(reportPath)) {
+	case ".txt":
+		return "text/plain; charset=utf-8"
+	case ".dot":
+		return "text/vnd.graphviz; charset=utf-8"
+	}
+
+	if value := mime.TypeByExtension(filepath.Ext(reportPath)); value != "" {
+		return value
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,137 @@
+package reports
+
+import (
+	"archivos_pro1/utils"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// graphvizFormats relaciona la extensión del archivo de salida con el formato de Graphviz
+var graphvizFormats = map[string]string{
+	".png":  "png",
+	".jpg":  "jpg",
+	".jpeg": "jpeg",
+	".svg":  "svg",
+	".pdf":  "pdf",
+}
+
+// graphReport es un modelo de reporte que se dibuja con Graphviz
+type graphReport interface {
+	dot() string
+}
+
+// textReport es un modelo de reporte que se escribe como texto plano
+type textReport interface {
+	text() string
+}
+
+// writeReport genera el reporte a partir de su modelo y lo registra para poder descargarlo desde el servidor.
+// Devuelve la ruta del archivo generado
+func writeReport(model interface{}, path string, format string) (string, error) {
+	outputPath, err := generateReport(model, path, format)
+	if err != nil {
+		return "", err
+	}
+
+	recordReport(outputPath)
+	return outputPath, nil
+}
+
+// generateReport escribe el modelo como JSON si format es "json",
+// o como imagen/texto según el tipo de reporte
+func generateReport(model interface{}, path string, format string) (string, error) {
+	if format == "json" {
+		return writeJSONReport(model, path)
+	}
+
+	switch report := model.(type) {
+	case graphReport:
+		return path, renderDot(report.dot(), path)
+	case textReport:
+		return writeTextReport(report.text(), path)
+	default:
+		return "", fmt.Errorf("el reporte %T no tiene una salida definida", model)
+	}
+}
+
+// writeJSONReport guarda el modelo del reporte como JSON con extensión .json y devuelve la ruta final
+func writeJSONReport(model interface{}, path string) (string, error) {
+	path = strings.TrimSuffix(path, filepath.Ext(path)) + ".json"
+
+	// Crear directorios padres si no existen
+	if err := utils.CreateParentDirs(path); err != nil {
+		return "", fmt.Errorf("error creando directorios padre: %v", err)
+	}
+
+	content, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error generando el JSON del reporte: %v", err)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return "", fmt.Errorf("error escribiendo el reporte: %v", err)
+	}
+
+	return path, nil
+}
+
+// renderDot guarda el código DOT junto a path y genera la salida en el formato que indica su extensión.
+// El archivo .dot se conserva aunque Graphviz falle o no esté instalado
+func renderDot(dotContent string, path string) error {
+	// Crear directorios padres si no existen
+	if err := utils.CreateParentDirs(path); err != nil {
+		return fmt.Errorf("error creando directorios padre: %v", err)
+	}
+
+	// Obtener nombres de archivo base y de salida
+	dotFileName, outputImage := utils.GetFileNames(path)
+
+	if err := os.WriteFile(dotFileName, []byte(dotContent), 0644); err != nil {
+		return fmt.Errorf("error escribiendo contenido DOT: %v", err)
+	}
+
+	// Si se pidió el .dot no hace falta ejecutar Graphviz
+	extension := strings.ToLower(filepath.Ext(path))
+	if extension == ".dot" {
+		return nil
+	}
+
+	format, ok := graphvizFormats[extension]
+	if !ok {
+		return fmt.Errorf("extensión %q no soportada, use .png, .jpg, .svg, .pdf o .dot (el código DOT se guardó en %s)", extension, dotFileName)
+	}
+
+	if _, err := exec.LookPath("dot"); err != nil {
+		return fmt.Errorf("Graphviz (dot) no está instalado, el código DOT se guardó en %s", dotFileName)
+	}
+
+	// Ejecutar Graphviz para generar la salida
+	output, err := exec.Command("dot", "-T"+format, dotFileName, "-o", outputImage).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error ejecutando Graphviz: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// writeTextReport guarda un reporte de solo texto con extensión .txt y devuelve la ruta final
+func writeTextReport(content string, path string) (string, error) {
+	if extension := filepath.Ext(path); !strings.EqualFold(extension, ".txt") {
+		path = strings.TrimSuffix(path, extension) + ".txt"
+	}
+
+	// Crear directorios padres si no existen
+	if err := utils.CreateParentDirs(path); err != nil {
+		return "", fmt.Errorf("error creando directorios padre: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("error escribiendo el reporte: %v", err)
+	}
+
+	return path, nil
+}
@@ -2,24 +2,110 @@ package reports
 
 import (
 	structures "archivos_pro1/Structures"
-	"archivos_pro1/utils"
 	"bytes"
 	"fmt"
-	"os"
-	"os/exec"
 	"strings"
 )
 
+// blockReport es el modelo del reporte de bloques
+type blockReport struct {
+	Blocks []blockModel `json:"blocks"`
+}
+
+// blockModel contiene el contenido de un bloque según su tipo (Pointer Block, Folder Block o File Block)
+type blockModel struct {
+	Index    int32              `json:"index"`
+	Type     string             `json:"type"`
+	Pointers []int32            `json:"pointers,omitempty"`
+	Entries  []folderEntryModel `json:"entries,omitempty"`
+	Content  string             `json:"content,omitempty"`
+}
+
+type folderEntryModel struct {
+	Name  string `json:"name"`
+	Inode int32  `json:"inode"`
+}
+
+// readBlockModel lee el bloque del disco y lo convierte en su modelo de reporte
+func readBlockModel(superblock *structures.SuperBlock, diskPath string, index int32, blockType string) (blockModel, error) {
+	model := blockModel{Index: index, Type: blockType}
+	blockStart := superblock.BlockOffset(index)
+
+	switch blockType {
+	case "Pointer Block":
+		pointerBlock := &structures.PointerBlock{}
+		if err := pointerBlock.Deserialize(diskPath, blockStart); err != nil {
+			return model, fmt.Errorf("error deserializando PointerBlock %d: %v", index, err)
+		}
+		model.Pointers = pointerBlock.P_pointers[:]
+
+	case "Folder Block":
+		folderBlock := &structures.FolderBlock{}
+		if err := folderBlock.Deserialize(diskPath, blockStart); err != nil {
+			return model, fmt.Errorf("error deserializando FolderBlock %d: %v", index, err)
+		}
+		for _, content := range folderBlock.B_content {
+			model.Entries = append(model.Entries, folderEntryModel{
+				Name:  string(bytes.Trim(content.B_name[:], "\x00")),
+				Inode: content.B_inodo,
+			})
+		}
+
+	default:
+		// Los bloques de archivo y los que ningún inodo apunta se muestran como contenido
+		fileBlock := &structures.FileBlock{}
+		if err := fileBlock.Deserialize(diskPath, blockStart); err != nil {
+			return model, fmt.Errorf("error deserializando FileBlock %d: %v", index, err)
+		}
+		// Convertir el contenido del bloque a string y eliminar los caracteres nulos
+		model.Content = cleanString(string(fileBlock.B_content[:]))
+	}
+
+	return model, nil
+}
+
 // ReportBlock genera un reporte visual de los bloques (pointer, folder, file) y lo guarda en la ruta especificada
-func ReportBlock(superblock *structures.SuperBlock, diskPath string, path string) error {
-	// Crear directorios padres si no existen
-	if err := utils.CreateParentDirs(path); err != nil {
-		return fmt.Errorf("error creando directorios padre: %v", err)
+func ReportBlock(superblock *structures.SuperBlock, diskPath string, path string, format string) error {
+	model := &blockReport{}
+
+	// Los bloques en uso son los marcados en el bitmap, aunque haya huecos entre ellos
+	bitmap, err := superblock.ReadBlockBitmap(diskPath)
+	if err != nil {
+		return err
+	}
+	types, err := superblock.BlockTypes(diskPath)
+	if err != nil {
+		return err
+	}
+
+	for i, value := range bitmap {
+		if value != 'X' {
+			continue
+		}
+
+		blockType, ok := types[int32(i)]
+		if !ok {
+			blockType = "Unreferenced Block"
+		}
+
+		block, err := readBlockModel(superblock, diskPath, int32(i), blockType)
+		if err != nil {
+			return err
+		}
+		model.Blocks = append(model.Blocks, block)
+	}
+
+	// Guardar el reporte en el formato pedido
+	outputPath, err := writeReport(model, path, format)
+	if err != nil {
+		return err
 	}
 
-	// Obtener nombres de archivo base y de salida
-	dotFileName, outputImage := utils.GetFileNames(path)
+	fmt.Printf("Reporte de bloques generado: %s\n", outputPath)
+	return nil
+}
 
+func (model *blockReport) dot() string {
 	// Iniciar el contenido DOT con una estructura profesional
 	dotContent := `digraph BlockReport {
 		rankdir=TB;
@@ -28,65 +114,30 @@ func ReportBlock(superblock *structures.SuperBlock, diskPath string, path string
 		edge [color=black, arrowhead=normal];
 	`
 
-	// Iterar sobre los bloques
-	for i := int32(0); i < superblock.S_blocks_count; i++ {
-		blockType := structures.BlocksMap[int(i)]
-		blockStart := int64(superblock.S_block_start + (i * superblock.S_block_size))
-
-		switch blockType {
+	for _, block := range model.Blocks {
+		switch block.Type {
 		case "Pointer Block":
-			pointerBlock := &structures.PointerBlock{}
-			if err := pointerBlock.Deserialize(diskPath, blockStart); err != nil {
-				return fmt.Errorf("error deserializando PointerBlock %d: %v", i, err)
-			}
-			dotContent += formatPointerBlock(i, pointerBlock)
-
+			dotContent += formatPointerBlock(block)
 		case "Folder Block":
-			folderBlock := &structures.FolderBlock{}
-			if err := folderBlock.Deserialize(diskPath, blockStart); err != nil {
-				return fmt.Errorf("error deserializando FolderBlock %d: %v", i, err)
-			}
-			dotContent += formatFolderBlock(i, folderBlock)
-
-		case "File Block":
-			fileBlock := &structures.FileBlock{}
-			if err := fileBlock.Deserialize(diskPath, blockStart); err != nil {
-				return fmt.Errorf("error deserializando FileBlock %d: %v", i, err)
-			}
-			dotContent += formatFileBlock(i, fileBlock)
+			dotContent += formatFolderBlock(block)
+		default:
+			dotContent += formatFileBlock(block)
 		}
 	}
 
 	// Finalizar el contenido DOT
 	dotContent += "}"
-
-	// Guardar el contenido DOT en un archivo
-	if file, err := os.Create(dotFileName); err == nil {
-		defer file.Close()
-		if _, err := file.WriteString(dotContent); err != nil {
-			return fmt.Errorf("error escribiendo contenido DOT: %v", err)
-		}
-	} else {
-		return fmt.Errorf("error creando archivo DOT: %v", err)
-	}
-
-	// Ejecutar Graphviz para generar la imagen
-	if err := exec.Command("dot", "-Tpng", dotFileName, "-o", outputImage).Run(); err != nil {
-		return fmt.Errorf("error ejecutando Graphviz: %v", err)
-	}
-
-	fmt.Printf("Reporte de bloques generado: %s\n", outputImage)
-	return nil
+	return dotContent
 }
 
 // formatPointerBlock genera el contenido DOT para un PointerBlock
-func formatPointerBlock(index int32, block *structures.PointerBlock) string {
+func formatPointerBlock(block blockModel) string {
 	content := fmt.Sprintf(`pointerBlock%d [label=<
 		<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
 			<tr><td colspan="2" bgcolor="#CCCCCC"><b>POINTER BLOCK %d</b></td></tr>
-	`, index, index)
+	`, block.Index, block.Index)
 
-	for i, pointer := range block.P_pointers {
+	for i, pointer := range block.Pointers {
 		content += fmt.Sprintf(`<tr><td>Pointer %d</td><td>%d</td></tr>`, i+1, pointer)
 	}
 
@@ -95,19 +146,17 @@ func formatPointerBlock(index int32, block *structures.PointerBlock) string {
 }
 
 // formatFolderBlock genera el contenido DOT para un FolderBlock
-func formatFolderBlock(index int32, block *structures.FolderBlock) string {
+func formatFolderBlock(block blockModel) string {
 	content := fmt.Sprintf(`folderBlock%d [label=<
 		<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
 			<tr><td colspan="2" bgcolor="#CCCCCC"><b>FOLDER BLOCK %d</b></td></tr>
-	`, index, index)
+	`, block.Index, block.Index)
 
-	for _, contentItem := range block.B_content {
-		name := string(bytes.Trim(contentItem.B_name[:], "\x00"))
-		inodo := contentItem.B_inodo
-		if inodo == 0 { // Verifica si el inodo es nulo o 0
-			content += fmt.Sprintf(`<tr><td>Name %s</td><td>-</td></tr>`, name)
+	for _, entry := range block.Entries {
+		if entry.Inode == -1 { // Las entradas libres apuntan al inodo -1; el 0 es la raíz
+			content += fmt.Sprintf(`<tr><td>Name %s</td><td>-</td></tr>`, escapeLabel(entry.Name))
 		} else {
-			content += fmt.Sprintf(`<tr><td>Name %s</td><td>%d</td></tr>`, name, inodo)
+			content += fmt.Sprintf(`<tr><td>Name %s</td><td>%d</td></tr>`, escapeLabel(entry.Name), entry.Inode)
 		}
 	}
 
@@ -115,18 +164,14 @@ func formatFolderBlock(index int32, block *structures.FolderBlock) string {
 	return content
 }
 
-// formatFileBlock genera el contenido DOT para un FileBlock
-func formatFileBlock(index int32, block *structures.FileBlock) string {
-	// Convertir el contenido del bloque a string y eliminar los caracteres nulos
-	fileContent := string(block.B_content[:])
-	fileContent = cleanString(fileContent)
-
+// formatFileBlock genera el contenido DOT para un FileBlock o un bloque sin referencia
+func formatFileBlock(block blockModel) string {
 	content := fmt.Sprintf(`fileBlock%d [label=<
 		<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
-			<tr><td colspan="2" bgcolor="#CCCCCC"><b>FILE BLOCK %d</b></td></tr>
-	`, index, index)
+			<tr><td colspan="2" bgcolor="#CCCCCC"><b>%s %d</b></td></tr>
+	`, block.Index, strings.ToUpper(block.Type), block.Index)
 
-	content += fmt.Sprintf(`<tr><td colspan="2">%s</td></tr>`, fileContent)
+	content += fmt.Sprintf(`<tr><td colspan="2">%s</td></tr>`, escapeLabel(block.Content))
 
 	content += "</table>>];\n"
 	return content
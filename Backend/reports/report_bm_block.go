@@ -2,69 +2,24 @@ package reports
 
 import (
 	structures "archivos_pro1/Structures"
-	"archivos_pro1/utils"
 	"fmt"
-	"os"
-	"strings"
 )
 
 // ReportBMBlock genera un reporte del bitmap de bloques y lo guarda en la ruta especificada
-func ReportBMBlock(superblock *structures.SuperBlock, diskPath string, path string) error {
-	// Crear las carpetas padre si no existen
-	err := utils.CreateParentDirs(path)
+func ReportBMBlock(superblock *structures.SuperBlock, diskPath string, path string, format string, options BitmapOptions) error {
+	// Leer el bitmap completo en una sola lectura
+	bitmap, err := superblock.ReadBlockBitmap(diskPath)
 	if err != nil {
-		return err
+		return fmt.Errorf("error al leer el bitmap de bloques: %v", err)
 	}
 
-	// Abrir el archivo de disco
-	file, err := os.Open(diskPath)
+	// Guardar el reporte en el formato pedido
+	model := newBitmapReport("blocks", bitmap, 'X', options)
+	outputPath, err := writeReport(model, path, format)
 	if err != nil {
-		return fmt.Errorf("error al abrir el archivo de disco: %v", err)
-	}
-	defer file.Close()
-
-	// Calcular el número total de inodos
-	totalBlocks := superblock.S_blocks_count + superblock.S_free_blocks_count
-
-	// Obtener el contenido del bitmap de inodos
-	var bitmapContent strings.Builder
-
-	for i := int32(0); i < totalBlocks; i++ {
-		// Establecer el puntero
-		_, err := file.Seek(int64(superblock.S_bm_block_start+i), 0)
-		if err != nil {
-			return fmt.Errorf("error al establecer el puntero en el archivo: %v", err)
-		}
-
-		// Leer un byte (carácter '0' o '1')
-		char := make([]byte, 1)
-		_, err = file.Read(char)
-		if err != nil {
-			return fmt.Errorf("error al leer el byte del archivo: %v", err)
-		}
-
-		// Agregar el carácter al contenido del bitmap
-		bitmapContent.WriteByte(char[0])
-
-		// Agregar un carácter de nueva línea cada 20 caracteres (20 inodos)
-		if (i+1)%20 == 0 {
-			bitmapContent.WriteString("\n")
-		}
-	}
-
-	// Crear el archivo TXT
-	txtFile, err := os.Create(path)
-	if err != nil {
-		return fmt.Errorf("error al crear el archivo TXT: %v", err)
-	}
-	defer txtFile.Close()
-
-	// Escribir el contenido del bitmap en el archivo TXT
-	_, err = txtFile.WriteString(bitmapContent.String())
-	if err != nil {
-		return fmt.Errorf("error al escribir en el archivo TXT: %v", err)
+		return err
 	}
 
-	fmt.Println("Archivo del bitmap de inodos generado:", path)
+	fmt.Println("Archivo del bitmap de bloques generado:", outputPath)
 	return nil
 }
@@ -2,69 +2,124 @@ package reports
 
 import (
 	structures "archivos_pro1/Structures"
-	"archivos_pro1/utils"
 	"fmt"
-	"os"
 	"strings"
 )
 
+// defaultBitmapWidth es la cantidad de registros por línea cuando no se indica -width
+const defaultBitmapWidth = 20
+
+// BitmapOptions configura la presentación de los reportes de bitmap
+type BitmapOptions struct {
+	Width   int  // Registros por línea; 0 usa el valor por defecto
+	Summary bool // Agregar al final los conteos de usados, libres y fragmentos
+}
+
 // ReportBMInode genera un reporte del bitmap de inodos y lo guarda en la ruta especificada
-func ReportBMInode(superblock *structures.SuperBlock, diskPath string, path string) error {
-	// Crear las carpetas padre si no existen
-	err := utils.CreateParentDirs(path)
+func ReportBMInode(superblock *structures.SuperBlock, diskPath string, path string, format string, options BitmapOptions) error {
+	// Leer el bitmap completo en una sola lectura
+	bitmap, err := superblock.ReadInodeBitmap(diskPath)
 	if err != nil {
-		return err
+		return fmt.Errorf("error al leer el bitmap de inodos: %v", err)
 	}
 
-	// Abrir el archivo de disco
-	file, err := os.Open(diskPath)
+	// Guardar el reporte en el formato pedido
+	model := newBitmapReport("inodes", bitmap, '1', options)
+	outputPath, err := writeReport(model, path, format)
 	if err != nil {
-		return fmt.Errorf("error al abrir el archivo de disco: %v", err)
+		return err
 	}
-	defer file.Close()
 
-	// Calcular el número total de inodos
-	totalInodes := superblock.S_inodes_count + superblock.S_free_inodes_count
+	fmt.Println("Archivo del bitmap de inodos generado:", outputPath)
+	return nil
+}
+
+// bitmapReport es el modelo de los reportes de bitmap; Bitmap contiene un carácter por inodo o bloque
+type bitmapReport struct {
+	Type    string         `json:"type"`
+	Bitmap  string         `json:"bitmap"`
+	Width   int            `json:"-"`
+	Summary *bitmapSummary `json:"summary,omitempty"`
+}
 
-	// Obtener el contenido del bitmap de inodos
-	var bitmapContent strings.Builder
+// bitmapSummary resume la ocupación de un bitmap
+type bitmapSummary struct {
+	Total          int `json:"total"`
+	Used           int `json:"used"`
+	Free           int `json:"free"`
+	UsedRuns       int `json:"used_runs"`        // Secuencias contiguas de registros usados
+	FreeRuns       int `json:"free_runs"`        // Secuencias contiguas de registros libres
+	LargestFreeRun int `json:"largest_free_run"` // Secuencia libre contigua más larga
+}
 
-	for i := int32(0); i < totalInodes; i++ {
-		// Establecer el puntero
-		_, err := file.Seek(int64(superblock.S_bm_inode_start+i), 0)
-		if err != nil {
-			return fmt.Errorf("error al establecer el puntero en el archivo: %v", err)
-		}
+// newBitmapReport arma el modelo del bitmap; used es el carácter que marca un registro ocupado
+func newBitmapReport(kind string, bitmap []byte, used byte, options BitmapOptions) *bitmapReport {
+	model := &bitmapReport{Type: kind, Bitmap: string(bitmap), Width: options.Width}
+	if model.Width <= 0 {
+		model.Width = defaultBitmapWidth
+	}
 
-		// Leer un byte (carácter '0' o '1')
-		char := make([]byte, 1)
-		_, err = file.Read(char)
-		if err != nil {
-			return fmt.Errorf("error al leer el byte del archivo: %v", err)
-		}
+	if options.Summary {
+		model.Summary = summarizeBitmap(bitmap, used)
+	}
 
-		// Agregar el carácter al contenido del bitmap
-		bitmapContent.WriteByte(char[0])
+	return model
+}
+
+// summarizeBitmap cuenta los registros usados y libres y las secuencias contiguas de cada uno
+func summarizeBitmap(bitmap []byte, used byte) *bitmapSummary {
+	summary := &bitmapSummary{Total: len(bitmap)}
 
-		// Agregar un carácter de nueva línea cada 20 caracteres (20 inodos)
-		if (i+1)%20 == 0 {
-			bitmapContent.WriteString("\n")
+	freeRun := 0
+	for i, value := range bitmap {
+		isUsed := value == used
+		newRun := i == 0 || (bitmap[i-1] == used) != isUsed
+
+		if isUsed {
+			summary.Used++
+			if newRun {
+				summary.UsedRuns++
+			}
+			freeRun = 0
+			continue
+		}
+
+		summary.Free++
+		if newRun {
+			summary.FreeRuns++
+		}
+		freeRun++
+		if freeRun > summary.LargestFreeRun {
+			summary.LargestFreeRun = freeRun
 		}
 	}
 
-	// Crear el archivo TXT
-	txtFile, err := os.Create(path)
-	if err != nil {
-		return fmt.Errorf("error al crear el archivo TXT: %v", err)
+	return summary
+}
+
+// text muestra el bitmap con Width registros por línea y, si se pidió, el resumen al final
+func (model *bitmapReport) text() string {
+	var content strings.Builder
+	for i := 0; i < len(model.Bitmap); i++ {
+		content.WriteByte(model.Bitmap[i])
+
+		// Agregar un carácter de nueva línea cada Width caracteres
+		if (i+1)%model.Width == 0 {
+			content.WriteString("\n")
+		}
 	}
-	defer txtFile.Close()
 
-	// Escribir el contenido del bitmap en el archivo TXT
-	_, err = txtFile.WriteString(bitmapContent.String())
-	if err != nil {
-		return fmt.Errorf("error al escribir en el archivo TXT: %v", err)
+	if model.Summary != nil {
+		if len(model.Bitmap)%model.Width != 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(fmt.Sprintf("\nTotal: %d\n", model.Summary.Total))
+		content.WriteString(fmt.Sprintf("Usados: %d\n", model.Summary.Used))
+		content.WriteString(fmt.Sprintf("Libres: %d\n", model.Summary.Free))
+		content.WriteString(fmt.Sprintf("Secuencias usadas: %d\n", model.Summary.UsedRuns))
+		content.WriteString(fmt.Sprintf("Secuencias libres: %d\n", model.Summary.FreeRuns))
+		content.WriteString(fmt.Sprintf("Secuencia libre más larga: %d\n", model.Summary.LargestFreeRun))
 	}
 
-	fmt.Println("Archivo del bitmap de inodos generado:", path)
-	return nil
+	return content.String()
 }
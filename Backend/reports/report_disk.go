@@ -0,0 +1,202 @@
+package reports
+
+import (
+	structures "archivos_pro1/Structures"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// diskReport es el modelo del reporte de disco
+type diskReport struct {
+	Path     string        `json:"path"`
+	Size     int64         `json:"size"`
+	Segments []diskSegment `json:"segments"`
+}
+
+// diskSegment representa una sección contigua del disco dentro del reporte
+type diskSegment struct {
+	Label      string        `json:"label"`              // Texto principal de la celda
+	Name       string        `json:"name,omitempty"`     // Nombre de la partición, si tiene
+	Size       int64         `json:"size"`               // Tamaño exacto en bytes
+	Percentage float64       `json:"percentage"`         // Porcentaje respecto al tamaño del disco
+	Color      string        `json:"-"`                  // Color de fondo de la celda
+	Children   []diskSegment `json:"children,omitempty"` // Secciones internas de una partición extendida
+}
+
+// ReportDisk genera el reporte de ocupación del disco con el tamaño exacto de cada partición,
+// EBR, partición lógica y espacio libre, y lo guarda en la ruta especificada
+func ReportDisk(mbr *structures.MBR, diskPath string, path string, format string) error {
+	segments, err := getDiskSegments(mbr, diskPath)
+	if err != nil {
+		return err
+	}
+
+	model := &diskReport{Path: diskPath, Size: int64(mbr.Mbr_size), Segments: segments}
+	setPercentages(model.Segments, model.Size)
+
+	// Guardar el reporte en el formato pedido
+	outputPath, err := writeReport(model, path, format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reporte de disco generado: %s\n", outputPath)
+	return nil
+}
+
+// setPercentages calcula el porcentaje de cada sección respecto al tamaño total del disco
+func setPercentages(segments []diskSegment, total int64) {
+	for i := range segments {
+		if total > 0 {
+			segments[i].Percentage = float64(segments[i].Size) / float64(total) * 100
+		}
+		setPercentages(segments[i].Children, total)
+	}
+}
+
+func (model *diskReport) dot() string {
+	var dotContent strings.Builder
+	dotContent.WriteString("digraph DiskStructure {\n")
+	dotContent.WriteString("  node [shape=none, fontname=\"Helvetica,Arial,sans-serif\"];\n")
+	dotContent.WriteString("  rankdir=TB;\n\n")
+	dotContent.WriteString("  disk [label=<\n")
+	dotContent.WriteString("    <table border='0' cellborder='1' cellspacing='0' cellpadding='10' bgcolor='#F5F5F5'>\n")
+	dotContent.WriteString(fmt.Sprintf("      <tr><td colspan='%d' bgcolor='#333333'><font color='white'>Disk Report %s</font></td></tr>\n", len(model.Segments), escapeLabel(model.Path)))
+	dotContent.WriteString("      <tr>\n")
+
+	for _, segment := range model.Segments {
+		if len(segment.Children) == 0 {
+			dotContent.WriteString("        " + formatDiskCell(segment) + "\n")
+			continue
+		}
+
+		// La partición extendida contiene una tabla con sus EBRs, lógicas y espacios libres
+		dotContent.WriteString("        <td cellpadding='0'>\n")
+		dotContent.WriteString("          <table border='0' cellborder='1' cellspacing='0' cellpadding='10'>\n")
+		dotContent.WriteString(fmt.Sprintf("            <tr><td colspan='%d' bgcolor='%s'><b>%s</b><br/>%s<br/>%s</td></tr>\n",
+			len(segment.Children), segment.Color, segment.Label, escapeLabel(segment.Name), formatSegmentSize(segment)))
+		dotContent.WriteString("            <tr>\n")
+		for _, child := range segment.Children {
+			dotContent.WriteString("              " + formatDiskCell(child) + "\n")
+		}
+		dotContent.WriteString("            </tr>\n")
+		dotContent.WriteString("          </table>\n")
+		dotContent.WriteString("        </td>\n")
+	}
+
+	dotContent.WriteString("      </tr>\n")
+	dotContent.WriteString("    </table>\n")
+	dotContent.WriteString("  >];\n")
+	dotContent.WriteString("}\n")
+	return dotContent.String()
+}
+
+// getDiskSegments recorre el disco en orden y devuelve el MBR, las particiones y los espacios libres
+func getDiskSegments(mbr *structures.MBR, diskPath string) ([]diskSegment, error) {
+	mbrSize := int64(binary.Size(mbr))
+	segments := []diskSegment{{Label: "MBR", Size: mbrSize, Color: "#FF6347"}}
+
+	// Ordenar las particiones usadas por su byte de inicio
+	var partitions []structures.Partition
+	for _, partition := range mbr.Mbr_partitions {
+		if partition.Part_start != -1 && partition.Part_size > 0 {
+			partitions = append(partitions, partition)
+		}
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].Part_start < partitions[j].Part_start
+	})
+
+	cursor := mbrSize
+	for _, partition := range partitions {
+		start := int64(partition.Part_start)
+		if start > cursor {
+			segments = append(segments, freeSegment(start-cursor))
+		}
+
+		segment := diskSegment{
+			Label: "Primaria",
+			Name:  strings.TrimRight(string(partition.Part_name[:]), "\x00"),
+			Size:  int64(partition.Part_size),
+			Color: "#ADD8E6",
+		}
+
+		if partition.Part_type[0] == 'E' {
+			segment.Label = "Extendida"
+			segment.Color = "#90EE90"
+			children, err := getExtendedSegments(partition, diskPath)
+			if err != nil {
+				return nil, err
+			}
+			segment.Children = children
+		}
+
+		segments = append(segments, segment)
+		cursor = start + int64(partition.Part_size)
+	}
+
+	if total := int64(mbr.Mbr_size); total > cursor {
+		segments = append(segments, freeSegment(total-cursor))
+	}
+
+	return segments, nil
+}
+
+// getExtendedSegments devuelve los EBRs, particiones lógicas y espacios libres de la partición extendida
+func getExtendedSegments(extended structures.Partition, diskPath string) ([]diskSegment, error) {
+	chain, err := structures.GetEBRChain(diskPath, extended.Part_start)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []diskSegment
+	cursor := int64(extended.Part_start)
+	for _, ebr := range chain {
+		// El EBR vacío del final no ocupa espacio de ninguna lógica
+		if ebr.Part_s <= 0 {
+			continue
+		}
+
+		location := int64(ebr.Part_start - structures.EBRReserved)
+		if location > cursor {
+			segments = append(segments, freeSegment(location-cursor))
+		}
+
+		segments = append(segments,
+			diskSegment{Label: "EBR", Size: structures.EBRReserved, Color: "#FF8C00"},
+			diskSegment{
+				Label: "Lógica",
+				Name:  strings.TrimRight(string(ebr.Part_name[:]), "\x00"),
+				Size:  int64(ebr.Part_s),
+				Color: "#FFD700",
+			})
+		cursor = int64(ebr.Part_start) + int64(ebr.Part_s)
+	}
+
+	if end := int64(extended.Part_start) + int64(extended.Part_size); end > cursor {
+		segments = append(segments, freeSegment(end-cursor))
+	}
+
+	return segments, nil
+}
+
+// freeSegment crea una sección de espacio libre del tamaño indicado
+func freeSegment(size int64) diskSegment {
+	return diskSegment{Label: "Libre", Size: size, Color: "#FFFFFF"}
+}
+
+// formatDiskCell genera la celda de una sección del disco
+func formatDiskCell(segment diskSegment) string {
+	label := "<b>" + segment.Label + "</b>"
+	if segment.Name != "" {
+		label += "<br/>" + escapeLabel(segment.Name)
+	}
+	return fmt.Sprintf("<td bgcolor='%s' align='center'>%s<br/>%s</td>", segment.Color, label, formatSegmentSize(segment))
+}
+
+// formatSegmentSize muestra el tamaño en bytes y su porcentaje respecto al disco
+func formatSegmentSize(segment diskSegment) string {
+	return fmt.Sprintf("%d bytes<br/>%.2f%%", segment.Size, segment.Percentage)
+}
@@ -0,0 +1,75 @@
+package reports
+
+import (
+	structures "archivos_pro1/Structures"
+	"archivos_pro1/utils"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// fileReport es el modelo del reporte de archivo: sus metadatos y el contenido completo
+type fileReport struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Size     int32  `json:"size"`
+	UID      int32  `json:"uid"`
+	GID      int32  `json:"gid"`
+	Perm     string `json:"perm"`
+	Created  string `json:"created"`
+	Modified string `json:"modified"`
+	Content  string `json:"content"`
+}
+
+// ReportFile escribe en path el contenido del archivo filePath de la partición, precedido por sus metadatos
+func ReportFile(superblock *structures.SuperBlock, diskPath string, path string, filePath string, format string) error {
+	// Buscar el inodo del archivo dentro de la partición
+	_, inode, err := superblock.FindInode(diskPath, filePath)
+	if err != nil {
+		return err
+	}
+	if inode.I_type[0] != '1' {
+		return fmt.Errorf("%s no es un archivo", filePath)
+	}
+
+	// Leer el contenido completo, incluyendo los bloques indirectos
+	content, err := superblock.ReadFileContent(diskPath, inode)
+	if err != nil {
+		return fmt.Errorf("error al leer el archivo %s: %v", filePath, err)
+	}
+
+	_, name := utils.GetParentDirectories(filePath)
+	model := &fileReport{
+		Name:     name,
+		Path:     filePath,
+		Size:     inode.I_size,
+		UID:      inode.I_uid,
+		GID:      inode.I_gid,
+		Perm:     string(inode.I_perm[:]),
+		Created:  time.Unix(int64(inode.I_ctime), 0).Format(time.RFC3339),
+		Modified: time.Unix(int64(inode.I_mtime), 0).Format(time.RFC3339),
+		Content:  content,
+	}
+
+	outputPath, err := writeReport(model, path, format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reporte de archivo generado: %s\n", outputPath)
+	return nil
+}
+
+// text muestra el encabezado con el nombre y los metadatos seguido del contenido
+func (model *fileReport) text() string {
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("Archivo: %s\n", model.Name))
+	report.WriteString(fmt.Sprintf("Ruta: %s\n", model.Path))
+	report.WriteString(fmt.Sprintf("Tamaño: %d bytes\n", model.Size))
+	report.WriteString(fmt.Sprintf("UID: %d  GID: %d  Permisos: %s\n", model.UID, model.GID, model.Perm))
+	report.WriteString(fmt.Sprintf("Creación: %s\n", model.Created))
+	report.WriteString(fmt.Sprintf("Modificación: %s\n", model.Modified))
+	report.WriteString(strings.Repeat("-", 40) + "\n")
+	report.WriteString(model.Content)
+	return report.String()
+}
@@ -0,0 +1,370 @@
+package reports
+
+import (
+	structures "archivos_pro1/Structures"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxHexLength limita el tamaño de la región que se puede volcar en un reporte hex
+const maxHexLength = 64 * 1024
+
+// hexRowWidth es la cantidad máxima de bytes por fila del volcado
+const hexRowWidth = 16
+
+// HexRegion indica la zona del disco que se vuelca en el reporte hex:
+// una región arbitraria (Offset, Length), un inodo, un bloque o el superbloque
+type HexRegion struct {
+	Offset     int64
+	Length     int64
+	Inode      int32 // Índice del inodo a volcar, o -1
+	Block      int32 // Índice del bloque a volcar, o -1
+	SuperBlock bool
+}
+
+// hexReport es el modelo del volcado hexadecimal anotado
+type hexReport struct {
+	Disk   string   `json:"disk"`
+	Offset int64    `json:"offset"`
+	Length int64    `json:"length"`
+	Rows   []hexRow `json:"rows"`
+}
+
+// hexRow es una fila del volcado; Field y Value solo se llenan si la fila pertenece a un campo conocido
+type hexRow struct {
+	Offset int64  `json:"offset"`
+	Field  string `json:"field,omitempty"`
+	Hex    string `json:"hex"`
+	ASCII  string `json:"ascii"`
+	Value  string `json:"value,omitempty"`
+}
+
+// hexField es un campo de una estructura serializada dentro del disco
+type hexField struct {
+	Start int64
+	Size  int64
+	Name  string
+	Kind  reflect.Kind // Int32, Float32 o Array para los arreglos de bytes
+}
+
+// ReportHex genera un volcado hexadecimal de la región pedida, anotado con los campos de
+// MBR, EBR, SuperBlock, inodos y bloques que la ocupan, y lo guarda en la ruta especificada
+func ReportHex(mbr *structures.MBR, superblock *structures.SuperBlock, sbStart int64, diskPath string, path string, region HexRegion, format string) error {
+	offset, length, err := resolveHexRegion(superblock, sbStart, region)
+	if err != nil {
+		return err
+	}
+
+	data, err := readDiskRegion(diskPath, offset, length)
+	if err != nil {
+		return err
+	}
+
+	fields, err := diskFields(mbr, superblock, sbStart, diskPath, offset, offset+length)
+	if err != nil {
+		return err
+	}
+
+	model := &hexReport{Disk: diskPath, Offset: offset, Length: length, Rows: buildHexRows(data, offset, fields)}
+
+	// Guardar el reporte en el formato pedido
+	outputPath, err := writeReport(model, path, format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reporte hex generado: %s\n", outputPath)
+	return nil
+}
+
+// resolveHexRegion convierte la región pedida en un byte inicial y una longitud
+func resolveHexRegion(sb *structures.SuperBlock, sbStart int64, region HexRegion) (int64, int64, error) {
+	switch {
+	case region.SuperBlock:
+		return sbStart, int64(binary.Size(sb)), nil
+	case region.Inode >= 0:
+		if region.Inode >= sb.S_inodes_count+sb.S_free_inodes_count {
+			return 0, 0, fmt.Errorf("el inodo %d no existe en la partición", region.Inode)
+		}
+		return sb.InodeOffset(region.Inode), int64(sb.S_inode_size), nil
+	case region.Block >= 0:
+		if region.Block >= sb.S_blocks_count+sb.S_free_blocks_count {
+			return 0, 0, fmt.Errorf("el bloque %d no existe en la partición", region.Block)
+		}
+		return sb.BlockOffset(region.Block), int64(sb.S_block_size), nil
+	}
+
+	if region.Offset < 0 || region.Length <= 0 {
+		return 0, 0, errors.New("la región del reporte hex no es válida")
+	}
+	if region.Length > maxHexLength {
+		return 0, 0, fmt.Errorf("la longitud máxima del reporte hex es %d bytes", maxHexLength)
+	}
+	return region.Offset, region.Length, nil
+}
+
+// readDiskRegion lee length bytes del disco a partir de offset
+func readDiskRegion(diskPath string, offset int64, length int64) ([]byte, error) {
+	disk, err := structures.OpenDisk(diskPath)
+	if err != nil {
+		return nil, fmt.Errorf("error al abrir el archivo de disco: %v", err)
+	}
+
+	size, err := disk.Size()
+	if err != nil {
+		return nil, err
+	}
+	if offset+length > size {
+		return nil, fmt.Errorf("la región %d-%d sale del disco de %d bytes", offset, offset+length, size)
+	}
+
+	data := make([]byte, length)
+	if err := structures.ReadDiskAt(diskPath, data, offset); err != nil {
+		return nil, fmt.Errorf("error al leer el disco: %v", err)
+	}
+	return data, nil
+}
+
+// diskFields devuelve, ordenados, los campos conocidos que se cruzan con la región [start, end)
+func diskFields(mbr *structures.MBR, sb *structures.SuperBlock, sbStart int64, diskPath string, start int64, end int64) ([]hexField, error) {
+	fields := structFields("MBR", reflect.TypeOf(*mbr), 0)
+
+	// EBRs de la partición extendida
+	for _, partition := range mbr.Mbr_partitions {
+		if partition.Part_type[0] != 'E' || partition.Part_start == -1 {
+			continue
+		}
+
+		chain, err := structures.GetEBRChain(diskPath, partition.Part_start)
+		if err != nil {
+			return nil, err
+		}
+
+		location := int64(partition.Part_start)
+		for i, ebr := range chain {
+			fields = append(fields, structFields(fmt.Sprintf("EBR %d", i+1), reflect.TypeOf(ebr), location)...)
+			location = int64(ebr.Part_next)
+		}
+	}
+
+	fields = append(fields, structFields("SuperBlock", reflect.TypeOf(*sb), sbStart)...)
+
+	// Los bitmaps se anotan como un único campo cada uno
+	totalInodes := sb.S_inodes_count + sb.S_free_inodes_count
+	totalBlocks := sb.S_blocks_count + sb.S_free_blocks_count
+	fields = append(fields,
+		hexField{Start: int64(sb.S_bm_inode_start), Size: int64(totalInodes), Name: "Bitmap inodos", Kind: reflect.Array},
+		hexField{Start: int64(sb.S_bm_block_start), Size: int64(totalBlocks), Name: "Bitmap bloques", Kind: reflect.Array})
+
+	// Solo los inodos y bloques que se cruzan con la región
+	first, last := overlappingIndexes(int64(sb.S_inode_start), int64(sb.S_inode_size), totalInodes, start, end)
+	for i := first; i < last; i++ {
+		fields = append(fields, structFields(fmt.Sprintf("Inodo %d", i), reflect.TypeOf(structures.Inode{}), sb.InodeOffset(i))...)
+	}
+
+	first, last = overlappingIndexes(int64(sb.S_block_start), int64(sb.S_block_size), totalBlocks, start, end)
+	if first < last {
+		types, err := sb.BlockTypes(diskPath)
+		if err != nil {
+			return nil, err
+		}
+		for i := first; i < last; i++ {
+			fields = append(fields, blockFields(sb, i, types[i])...)
+		}
+	}
+
+	// Descartar los campos fuera de la región y ordenar por posición
+	var visible []hexField
+	for _, field := range fields {
+		if field.Start < end && field.Start+field.Size > start {
+			visible = append(visible, field)
+		}
+	}
+	sort.SliceStable(visible, func(i, j int) bool { return visible[i].Start < visible[j].Start })
+
+	return visible, nil
+}
+
+// overlappingIndexes devuelve el rango [first, last) de elementos de una tabla que se cruzan con [start, end)
+func overlappingIndexes(tableStart int64, size int64, count int32, start int64, end int64) (int32, int32) {
+	if size <= 0 || end <= tableStart {
+		return 0, 0
+	}
+
+	first := (start - tableStart) / size
+	if first < 0 {
+		first = 0
+	}
+	last := (end - tableStart + size - 1) / size
+	if last > int64(count) {
+		last = int64(count)
+	}
+	if first > last {
+		return 0, 0
+	}
+	return int32(first), int32(last)
+}
+
+// blockFields anota un bloque según el tipo que le da el inodo que lo apunta; si ninguno lo apunta, se muestra completo
+func blockFields(sb *structures.SuperBlock, index int32, blockType string) []hexField {
+	name := fmt.Sprintf("Bloque %d", index)
+	offset := sb.BlockOffset(index)
+
+	switch blockType {
+	case "Folder Block":
+		return structFields(name, reflect.TypeOf(structures.FolderBlock{}), offset)
+	case "Pointer Block":
+		return structFields(name, reflect.TypeOf(structures.PointerBlock{}), offset)
+	case "File Block":
+		return structFields(name, reflect.TypeOf(structures.FileBlock{}), offset)
+	}
+
+	return []hexField{{Start: offset, Size: int64(sb.S_block_size), Name: name, Kind: reflect.Array}}
+}
+
+// structFields calcula la posición de cada campo de una estructura serializada con binary.Write;
+// las estructuras y los arreglos que no son de bytes se expanden campo por campo
+func structFields(prefix string, t reflect.Type, start int64) []hexField {
+	var fields []hexField
+	offset := start
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := prefix + "." + field.Name
+		fields = append(fields, typeFields(name, field.Type, offset)...)
+		offset += int64(binary.Size(reflect.Zero(field.Type).Interface()))
+	}
+
+	return fields
+}
+
+// typeFields devuelve los campos de un valor de tipo t que inicia en start
+func typeFields(name string, t reflect.Type, start int64) []hexField {
+	size := int64(binary.Size(reflect.Zero(t).Interface()))
+
+	switch {
+	case t.Kind() == reflect.Struct:
+		return structFields(name, t, start)
+	case t.Kind() == reflect.Array && t.Elem().Kind() != reflect.Uint8:
+		var fields []hexField
+		elemSize := size / int64(t.Len())
+		for i := 0; i < t.Len(); i++ {
+			fields = append(fields, typeFields(fmt.Sprintf("%s[%d]", name, i), t.Elem(), start+int64(i)*elemSize)...)
+		}
+		return fields
+	}
+
+	return []hexField{{Start: start, Size: size, Name: name, Kind: t.Kind()}}
+}
+
+// buildHexRows divide los datos en filas que nunca cruzan el límite de un campo
+func buildHexRows(data []byte, offset int64, fields []hexField) []hexRow {
+	var rows []hexRow
+	end := offset + int64(len(data))
+	cursor := offset
+	next := 0
+
+	for cursor < end {
+		// Saltar los campos que ya terminaron
+		for next < len(fields) && fields[next].Start+fields[next].Size <= cursor {
+			next++
+		}
+
+		rowEnd := min(cursor+hexRowWidth, end)
+		row := hexRow{Offset: cursor}
+
+		if next < len(fields) && fields[next].Start <= cursor {
+			// La fila pertenece a un campo conocido
+			field := fields[next]
+			rowEnd = min(rowEnd, field.Start+field.Size)
+			if cursor == field.Start {
+				row.Field = field.Name
+				if rowEnd == field.Start+field.Size {
+					row.Value = decodeField(field, data[cursor-offset:rowEnd-offset])
+				}
+			}
+		} else if next < len(fields) {
+			// Bytes sin anotar hasta el siguiente campo
+			rowEnd = min(rowEnd, fields[next].Start)
+		}
+
+		chunk := data[cursor-offset : rowEnd-offset]
+		row.Hex = formatHexBytes(chunk)
+		row.ASCII = formatASCII(chunk)
+		rows = append(rows, row)
+		cursor = rowEnd
+	}
+
+	return rows
+}
+
+// decodeField interpreta los bytes de un campo completo según su tipo
+func decodeField(field hexField, value []byte) string {
+	switch field.Kind {
+	case reflect.Int32:
+		return fmt.Sprintf("%d", int32(binary.LittleEndian.Uint32(value)))
+	case reflect.Float32:
+		number := math.Float32frombits(binary.LittleEndian.Uint32(value))
+		// Las fechas se guardan como segundos Unix en un float32
+		lowerName := strings.ToLower(field.Name)
+		if strings.HasSuffix(lowerName, "time") || strings.HasSuffix(lowerName, "date") {
+			return fmt.Sprintf("%g (%s)", number, time.Unix(int64(number), 0).Format("2006-01-02 15:04:05"))
+		}
+		return fmt.Sprintf("%g", number)
+	case reflect.Array:
+		// Los bitmaps y bloques completos solo se muestran en hexadecimal
+		if field.Size > hexRowWidth {
+			return ""
+		}
+		return fmt.Sprintf("%q", strings.TrimRight(string(value), "\x00"))
+	}
+	return ""
+}
+
+// formatHexBytes muestra los bytes en hexadecimal separados por espacios
+func formatHexBytes(chunk []byte) string {
+	parts := make([]string, len(chunk))
+	for i, b := range chunk {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatASCII muestra los bytes imprimibles y reemplaza los demás por un punto
+func formatASCII(chunk []byte) string {
+	var ascii strings.Builder
+	for _, b := range chunk {
+		if b >= 0x20 && b < 0x7F {
+			ascii.WriteByte(b)
+		} else {
+			ascii.WriteByte('.')
+		}
+	}
+	return ascii.String()
+}
+
+func (model *hexReport) text() string {
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("Disco: %s\n", model.Disk))
+	content.WriteString(fmt.Sprintf("Región: %d - %d (%d bytes)\n", model.Offset, model.Offset+model.Length, model.Length))
+	content.WriteString(strings.Repeat("-", 100) + "\n")
+
+	for _, row := range model.Rows {
+		line := fmt.Sprintf("%08X  %-47s  |%-16s|", row.Offset, row.Hex, row.ASCII)
+		if row.Field != "" {
+			line += "  " + row.Field
+		}
+		if row.Value != "" {
+			line += " = " + row.Value
+		}
+		content.WriteString(line + "\n")
+	}
+
+	return content.String()
+}
@@ -2,99 +2,163 @@ package reports
 
 import (
 	structures "archivos_pro1/Structures"
-	"archivos_pro1/utils"
 	"fmt"
-	"os"
-	"os/exec"
+	"sort"
+	"strings"
 	"time"
 )
 
-// ReportInode genera un reporte visual de los inodos y lo guarda en la ruta especificada
-func ReportInode(superblock *structures.SuperBlock, diskPath string, path string) error {
-	// Crear directorios padres si no existen
-	if err := utils.CreateParentDirs(path); err != nil {
-		return fmt.Errorf("error creando directorios padre: %v", err)
+// inodeReport es el modelo del reporte de inodos
+type inodeReport struct {
+	Inodes []inodeEntry `json:"inodes"`
+}
+
+// inodeModel contiene los atributos de un inodo tal como se muestran en los reportes
+type inodeModel struct {
+	Index  int32     `json:"index"`
+	UID    int32     `json:"uid"`
+	GID    int32     `json:"gid"`
+	Size   int32     `json:"size"`
+	Atime  string    `json:"atime"`
+	Ctime  string    `json:"ctime"`
+	Mtime  string    `json:"mtime"`
+	Type   string    `json:"type"`
+	Perm   string    `json:"perm"`
+	Blocks [15]int32 `json:"blocks"`
+}
+
+// newInodeModel convierte el inodo leído del disco en su modelo de reporte
+func newInodeModel(index int32, inode *structures.Inode) inodeModel {
+	return inodeModel{
+		Index:  index,
+		UID:    inode.I_uid,
+		GID:    inode.I_gid,
+		Size:   inode.I_size,
+		Atime:  time.Unix(int64(inode.I_atime), 0).Format(time.RFC3339),
+		Ctime:  time.Unix(int64(inode.I_ctime), 0).Format(time.RFC3339),
+		Mtime:  time.Unix(int64(inode.I_mtime), 0).Format(time.RFC3339),
+		Type:   string(inode.I_type[:]),
+		Perm:   string(inode.I_perm[:]),
+		Blocks: inode.I_block,
 	}
+}
 
-	// Obtener nombres de archivo base y de salida
-	dotFileName, outputImage := utils.GetFileNames(path)
+// inodeEntry agrega al modelo del inodo los datos legibles que muestra el reporte de inodos
+type inodeEntry struct {
+	inodeModel
+	Owner       string `json:"owner"`
+	Group       string `json:"group"`
+	Permissions string `json:"permissions"`
+}
 
-	// Iniciar el contenido DOT con una estructura profesional
-	dotContent := `digraph InodeReport {
-		rankdir=TB;
-		node [shape=none, fontname="Helvetica, Arial, sans-serif"];
-		graph [splines=true, nodesep=0.5, ranksep=0.4];
-		edge [color=black, arrowhead=normal];
-	`
+// ReportInode genera un reporte visual de los inodos usados según el bitmap y lo guarda en la ruta especificada
+func ReportInode(superblock *structures.SuperBlock, diskPath string, path string, format string) error {
+	// Los inodos usados pueden estar en cualquier posición, por eso se consulta el bitmap
+	used, err := superblock.UsedInodes(diskPath)
+	if err != nil {
+		return fmt.Errorf("error al leer el bitmap de inodos: %v", err)
+	}
 
-	// Iterar sobre los inodos en el superblock
-	for i := int32(0); i < superblock.S_inodes_count; i++ {
-		inode := &structures.Inode{}
-		inodeStart := int64(superblock.S_inode_start + (i * superblock.S_inode_size))
+	// Leer users.txt para mostrar los nombres de propietario y grupo
+	usersContent, err := superblock.ReadUsersFile(diskPath)
+	if err != nil {
+		return fmt.Errorf("error al leer users.txt: %v", err)
+	}
+	groups, users := structures.ParseUsersFile(usersContent)
 
-		// Deserializar el inodo desde el disco
-		if err := inode.Deserialize(diskPath, inodeStart); err != nil {
-			return fmt.Errorf("error al deserializar inodo %d: %v", i, err)
+	model := &inodeReport{}
+	for _, index := range used {
+		inode := &structures.Inode{}
+		if err := inode.Deserialize(diskPath, superblock.InodeOffset(index)); err != nil {
+			return fmt.Errorf("error al deserializar inodo %d: %v", index, err)
 		}
 
-		// Formatear las fechas como cadenas
-		atime := time.Unix(int64(inode.I_atime), 0).Format(time.RFC3339)
-		ctime := time.Unix(int64(inode.I_ctime), 0).Format(time.RFC3339)
-		mtime := time.Unix(int64(inode.I_mtime), 0).Format(time.RFC3339)
-
-		// Generar el contenido DOT para el inodo actual
-		dotContent += fmt.Sprintf(`inode%d [label=<
-			<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
-				<tr><td colspan="2" bgcolor="#CCCCCC"><b>INODE REPORT %d</b></td></tr>
-				<tr><td><b>UID</b></td><td>%d</td></tr>
-				<tr><td><b>GID</b></td><td>%d</td></tr>
-				<tr><td><b>Size</b></td><td>%d</td></tr>
-				<tr><td><b>Access Time</b></td><td>%s</td></tr>
-				<tr><td><b>Creation Time</b></td><td>%s</td></tr>
-				<tr><td><b>Modification Time</b></td><td>%s</td></tr>
-				<tr><td><b>Type</b></td><td>%c</td></tr>
-				<tr><td><b>Permissions</b></td><td>%s</td></tr>
-				<tr><td colspan="2" bgcolor="#E0E0E0"><b>Direct Blocks</b></td></tr>
-		`, i, i, inode.I_uid, inode.I_gid, inode.I_size, atime, ctime, mtime, rune(inode.I_type[0]), string(inode.I_perm[:]))
-
-		// Incluir los bloques directos
-		for j, block := range inode.I_block[:12] {
-			dotContent += fmt.Sprintf(`<tr><td>Block %d</td><td>%d</td></tr>`, j+1, block)
-		}
+		model.Inodes = append(model.Inodes, inodeEntry{
+			inodeModel:  newInodeModel(index, inode),
+			Owner:       structures.UserNameByID(users, inode.I_uid),
+			Group:       structures.GroupNameByID(groups, inode.I_gid),
+			Permissions: inode.PermissionString(),
+		})
+	}
 
-		// Incluir los bloques indirectos
-		dotContent += fmt.Sprintf(`
-			<tr><td colspan="2" bgcolor="#E0E0E0"><b>Indirect Blocks</b></td></tr>
-			<tr><td>Single Indirect</td><td>%d</td></tr>
-			<tr><td>Double Indirect</td><td>%d</td></tr>
-			<tr><td>Triple Indirect</td><td>%d</td></tr>
-			</table>>];
-		`, inode.I_block[12], inode.I_block[13], inode.I_block[14])
-
-		// Conectar inodos secuenciales
-		if i < superblock.S_inodes_count-1 {
-			dotContent += fmt.Sprintf("inode%d -> inode%d;\n", i, i+1)
-		}
+	// Guardar el reporte en el formato pedido
+	outputPath, err := writeReport(model, path, format)
+	if err != nil {
+		return err
 	}
 
-	// Finalizar el contenido DOT
-	dotContent += "}"
+	fmt.Printf("Reporte de inodos generado: %s\n", outputPath)
+	return nil
+}
+
+// blockPointerNames nombra cada una de las 15 posiciones de I_block
+var blockPointerNames = [15]string{
+	"Block 1", "Block 2", "Block 3", "Block 4", "Block 5", "Block 6",
+	"Block 7", "Block 8", "Block 9", "Block 10", "Block 11", "Block 12",
+	"Single Indirect", "Double Indirect", "Triple Indirect",
+}
 
-	// Guardar el contenido DOT en un archivo
-	if file, err := os.Create(dotFileName); err == nil {
-		defer file.Close()
-		if _, err := file.WriteString(dotContent); err != nil {
-			return fmt.Errorf("error escribiendo contenido DOT: %v", err)
+func (model *inodeReport) dot() string {
+	var dotContent strings.Builder
+	dotContent.WriteString(`digraph InodeReport {
+	rankdir=LR;
+	node [shape=none, fontname="Helvetica, Arial, sans-serif"];
+	graph [splines=true, nodesep=0.5, ranksep=0.8];
+	edge [color=black, arrowhead=normal];
+`)
+
+	// Bloques referenciados por algún inodo, para dibujar cada nodo una sola vez
+	referenced := map[int32]bool{}
+	var edges strings.Builder
+
+	for _, inode := range model.Inodes {
+		inodeType := "Carpeta"
+		if inode.Type == "1" {
+			inodeType = "Archivo"
 		}
-	} else {
-		return fmt.Errorf("error creando archivo DOT: %v", err)
+
+		dotContent.WriteString(fmt.Sprintf(`	inode%d [label=<
+		<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
+			<tr><td colspan="2" bgcolor="#CCCCCC"><b>INODE %d</b></td></tr>
+			<tr><td><b>Type</b></td><td>%s</td></tr>
+			<tr><td><b>Permissions</b></td><td>%s (%s)</td></tr>
+			<tr><td><b>Owner</b></td><td>%s (%d)</td></tr>
+			<tr><td><b>Group</b></td><td>%s (%d)</td></tr>
+			<tr><td><b>Size</b></td><td>%d</td></tr>
+			<tr><td><b>Access Time</b></td><td>%s</td></tr>
+			<tr><td><b>Creation Time</b></td><td>%s</td></tr>
+			<tr><td><b>Modification Time</b></td><td>%s</td></tr>
+			<tr><td colspan="2" bgcolor="#E0E0E0"><b>I_block</b></td></tr>
+`, inode.Index, inode.Index, inodeType, escapeLabel(inode.Permissions), escapeLabel(inode.Perm),
+			escapeLabel(inode.Owner), inode.UID, escapeLabel(inode.Group), inode.GID,
+			inode.Size, inode.Atime, inode.Ctime, inode.Mtime))
+
+		// Las 15 posiciones de I_block, con un puerto en las que apuntan a un bloque
+		for j, block := range inode.Blocks {
+			if block == -1 {
+				dotContent.WriteString(fmt.Sprintf("\t\t\t<tr><td>%s</td><td>-1</td></tr>\n", blockPointerNames[j]))
+				continue
+			}
+
+			dotContent.WriteString(fmt.Sprintf("\t\t\t<tr><td>%s</td><td port=\"b%d\">%d</td></tr>\n", blockPointerNames[j], j, block))
+			edges.WriteString(fmt.Sprintf("\tinode%d:b%d -> block%d;\n", inode.Index, j, block))
+			referenced[block] = true
+		}
+
+		dotContent.WriteString("\t\t</table>>];\n")
 	}
 
-	// Ejecutar Graphviz para generar la imagen
-	if err := exec.Command("dot", "-Tpng", dotFileName, "-o", outputImage).Run(); err != nil {
-		return fmt.Errorf("error ejecutando Graphviz: %v", err)
+	// Nodos de los bloques referenciados, en orden de índice
+	blocks := make([]int32, 0, len(referenced))
+	for block := range referenced {
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+	for _, block := range blocks {
+		dotContent.WriteString(fmt.Sprintf("\tblock%d [shape=box, style=filled, fillcolor=\"#ADD8E6\", label=\"Block %d\"];\n", block, block))
 	}
 
-	fmt.Printf("Reporte de inodos generado: %s\n", outputImage)
-	return nil
+	dotContent.WriteString(edges.String())
+	dotContent.WriteString("}\n")
+	return dotContent.String()
 }
@@ -0,0 +1,108 @@
+package reports
+
+import (
+	structures "archivos_pro1/Structures"
+	"fmt"
+	"time"
+)
+
+// lsReport es el modelo del reporte ls de una carpeta
+type lsReport struct {
+	Path    string         `json:"path"`
+	Entries []lsEntryModel `json:"entries"`
+}
+
+type lsEntryModel struct {
+	Permissions string `json:"permissions"`
+	Owner       string `json:"owner"`
+	Group       string `json:"group"`
+	Size        int32  `json:"size"`
+	Date        string `json:"date"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+}
+
+// ReportLs genera una tabla con las entradas de la carpeta folderPath y la guarda en la ruta especificada
+func ReportLs(superblock *structures.SuperBlock, diskPath string, path string, folderPath string, format string) error {
+	// Buscar la carpeta dentro de la partición
+	_, folder, err := superblock.FindInode(diskPath, folderPath)
+	if err != nil {
+		return err
+	}
+	if folder.I_type[0] != '0' {
+		return fmt.Errorf("%s no es una carpeta", folderPath)
+	}
+
+	entries, err := superblock.ListFolder(diskPath, folder)
+	if err != nil {
+		return err
+	}
+
+	// Leer users.txt para mostrar los nombres de propietario y grupo
+	usersContent, err := superblock.ReadUsersFile(diskPath)
+	if err != nil {
+		return fmt.Errorf("error al leer users.txt: %v", err)
+	}
+	groups, users := structures.ParseUsersFile(usersContent)
+
+	model := &lsReport{Path: folderPath}
+
+	// Una fila por cada entrada de la carpeta
+	for _, entry := range entries {
+		inode := &structures.Inode{}
+		if err := inode.Deserialize(diskPath, superblock.InodeOffset(entry.Inode)); err != nil {
+			return fmt.Errorf("error al deserializar inodo %d: %v", entry.Inode, err)
+		}
+
+		entryType := "Carpeta"
+		if inode.I_type[0] == '1' {
+			entryType = "Archivo"
+		}
+
+		model.Entries = append(model.Entries, lsEntryModel{
+			Permissions: inode.PermissionString(),
+			Owner:       structures.UserNameByID(users, inode.I_uid),
+			Group:       structures.GroupNameByID(groups, inode.I_gid),
+			Size:        inode.I_size,
+			Date:        time.Unix(int64(inode.I_mtime), 0).Format("2006-01-02 15:04:05"),
+			Type:        entryType,
+			Name:        entry.Name,
+		})
+	}
+
+	// Guardar el reporte en el formato pedido
+	outputPath, err := writeReport(model, path, format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reporte ls generado: %s\n", outputPath)
+	return nil
+}
+
+func (model *lsReport) dot() string {
+	dotContent := fmt.Sprintf(`digraph LsReport {
+		node [shape=none, fontname="Helvetica, Arial, sans-serif"];
+		ls [label=<
+			<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
+				<tr><td colspan="7" bgcolor="#CCCCCC"><b>LS %s</b></td></tr>
+				<tr>
+					<td bgcolor="#E0E0E0"><b>Permisos</b></td>
+					<td bgcolor="#E0E0E0"><b>Owner</b></td>
+					<td bgcolor="#E0E0E0"><b>Grupo</b></td>
+					<td bgcolor="#E0E0E0"><b>Size (Bytes)</b></td>
+					<td bgcolor="#E0E0E0"><b>Fecha</b></td>
+					<td bgcolor="#E0E0E0"><b>Tipo</b></td>
+					<td bgcolor="#E0E0E0"><b>Name</b></td>
+				</tr>
+	`, escapeLabel(model.Path))
+
+	for _, entry := range model.Entries {
+		dotContent += fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td></tr>
+		`, entry.Permissions, escapeLabel(entry.Owner), escapeLabel(entry.Group), entry.Size, entry.Date, entry.Type, escapeLabel(entry.Name))
+	}
+
+	dotContent += `</table>>];
+	}`
+	return dotContent
+}
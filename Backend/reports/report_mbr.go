@@ -2,24 +2,101 @@ package reports
 
 import (
 	structures "archivos_pro1/Structures"
-	"archivos_pro1/utils"
 	"fmt"
-	"os"
-	"os/exec"
 	"strings"
 	"time"
 )
 
-func ReportMBR(mbr *structures.MBR, path string) error {
-	// Crear las carpetas padre si no existen
-	err := utils.CreateParentDirs(path)
+// mbrReport es el modelo del reporte del MBR y de los EBRs de la partición extendida
+type mbrReport struct {
+	Size         int32             `json:"size"`
+	CreationDate string            `json:"creation_date"`
+	Signature    int32             `json:"disk_signature"`
+	Fit          string            `json:"fit"`
+	Partitions   []partitionReport `json:"partitions"`
+	EBRs         []ebrReport       `json:"ebrs"`
+}
+
+type partitionReport struct {
+	Status string `json:"status"`
+	Type   string `json:"type"`
+	Fit    string `json:"fit"`
+	Start  int32  `json:"start"`
+	Size   int32  `json:"size"`
+	Name   string `json:"name"`
+}
+
+type ebrReport struct {
+	Mount string `json:"mount"`
+	Fit   string `json:"fit"`
+	Start int32  `json:"start"`
+	Size  int32  `json:"size"`
+	Next  int32  `json:"next"`
+	Name  string `json:"name"`
+}
+
+func ReportMBR(mbr *structures.MBR, diskPath string, path string, format string) error {
+	model, err := buildMBRReport(mbr, diskPath)
 	if err != nil {
 		return err
 	}
 
-	// Obtener el nombre base del archivo sin la extensión
-	dotFileName, outputImage := utils.GetFileNames(path)
+	outputPath, err := writeReport(model, path, format)
+	if err != nil {
+		return err
+	}
 
+	fmt.Println("Imagen de la tabla generada:", outputPath)
+	return nil
+}
+
+// buildMBRReport arma el modelo con las cuatro particiones y la cadena de EBRs
+func buildMBRReport(mbr *structures.MBR, diskPath string) (*mbrReport, error) {
+	model := &mbrReport{
+		Size:         mbr.Mbr_size,
+		CreationDate: time.Unix(int64(mbr.Mbr_creation_date), 0).Format("2006-01-02 15:04:05"),
+		Signature:    mbr.Mbr_disk_signature,
+		Fit:          trimNulls(mbr.Mbr_disk_fit[:]),
+	}
+
+	for _, part := range mbr.Mbr_partitions {
+		model.Partitions = append(model.Partitions, partitionReport{
+			Status: trimNulls(part.Part_status[:]),
+			Type:   trimNulls(part.Part_type[:]),
+			Fit:    trimNulls(part.Part_fit[:]),
+			Start:  part.Part_start,
+			Size:   part.Part_size,
+			Name:   trimNulls(part.Part_name[:]),
+		})
+	}
+
+	// Agregar los EBRs de la partición extendida, en el orden de la cadena
+	for _, part := range mbr.Mbr_partitions {
+		if part.Part_type[0] != 'E' || part.Part_start == -1 {
+			continue
+		}
+
+		chain, err := structures.GetEBRChain(diskPath, part.Part_start)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ebr := range chain {
+			model.EBRs = append(model.EBRs, ebrReport{
+				Mount: trimNulls(ebr.Part_mount[:]),
+				Fit:   trimNulls(ebr.Part_fit[:]),
+				Start: ebr.Part_start,
+				Size:  ebr.Part_s,
+				Next:  ebr.Part_next,
+				Name:  trimNulls(ebr.Part_name[:]),
+			})
+		}
+	}
+
+	return model, nil
+}
+
+func (model *mbrReport) dot() string {
 	// Definir el contenido DOT con una tabla estilizada
 	dotContent := fmt.Sprintf(`digraph G {
         node [shape=plaintext, fontname="Helvetica, Arial, sans-serif"]
@@ -29,17 +106,10 @@ func ReportMBR(mbr *structures.MBR, path string) error {
                 <tr bgcolor="#dddddd"><td><b>mbr_tamano</b></td><td>%d</td></tr>
                 <tr><td><b>mbr_fecha_creacion</b></td><td>%s</td></tr>
                 <tr bgcolor="#dddddd"><td><b>mbr_disk_signature</b></td><td>%d</td></tr>
-            `, mbr.Mbr_size, time.Unix(int64(mbr.Mbr_creation_date), 0).Format("2006-01-02 15:04:05"), mbr.Mbr_disk_signature)
+            `, model.Size, model.CreationDate, model.Signature)
 
 	// Agregar las particiones a la tabla
-	for i, part := range mbr.Mbr_partitions {
-		// Convertir Part_name a string y eliminar los caracteres nulos
-		partName := strings.TrimRight(string(part.Part_name[:]), "\x00")
-		// Convertir Part_status, Part_type y Part_fit a char
-		partStatus := rune(part.Part_status[0])
-		partType := rune(part.Part_type[0])
-		partFit := rune(part.Part_fit[0])
-
+	for i, part := range model.Partitions {
 		// Definir un color de fondo alternado para las particiones
 		bgColor := "#ffffff"
 		if i%2 == 0 {
@@ -49,37 +119,34 @@ func ReportMBR(mbr *structures.MBR, path string) error {
 		// Agregar la partición a la tabla
 		dotContent += fmt.Sprintf(`
 				<tr><td colspan="2" bgcolor="#4CAF50" align="center"><b>PARTICIÓN %d</b></td></tr>
-				<tr bgcolor="%s"><td><b>part_status</b></td><td>%c</td></tr>
-				<tr bgcolor="#ffffff"><td><b>part_type</b></td><td>%c</td></tr>
-				<tr bgcolor="%s"><td><b>part_fit</b></td><td>%c</td></tr>
+				<tr bgcolor="%s"><td><b>part_status</b></td><td>%s</td></tr>
+				<tr bgcolor="#ffffff"><td><b>part_type</b></td><td>%s</td></tr>
+				<tr bgcolor="%s"><td><b>part_fit</b></td><td>%s</td></tr>
 				<tr bgcolor="#ffffff"><td><b>part_start</b></td><td>%d</td></tr>
 				<tr bgcolor="%s"><td><b>part_size</b></td><td>%d</td></tr>
 				<tr bgcolor="#ffffff"><td><b>part_name</b></td><td>%s</td></tr>
-			`, i+1, bgColor, partStatus, partType, bgColor, partFit, part.Part_start, bgColor, part.Part_size, partName)
-	}
-
-	// Cerrar la tabla y el contenido DOT
-	dotContent += "</table>>] }"
-
-	// Guardar el contenido DOT en un archivo
-	file, err := os.Create(dotFileName)
-	if err != nil {
-		return fmt.Errorf("error al crear el archivo: %v", err)
+			`, i+1, bgColor, escapeLabel(part.Status), escapeLabel(part.Type), bgColor, escapeLabel(part.Fit), part.Start, bgColor, part.Size, escapeLabel(part.Name))
 	}
-	defer file.Close()
 
-	_, err = file.WriteString(dotContent)
-	if err != nil {
-		return fmt.Errorf("error al escribir en el archivo: %v", err)
+	// Agregar los EBRs
+	for i, ebr := range model.EBRs {
+		dotContent += fmt.Sprintf(`
+				<tr><td colspan="2" bgcolor="#FF8C00" align="center"><b>EBR %d</b></td></tr>
+				<tr bgcolor="#eeeeee"><td><b>part_mount</b></td><td>%s</td></tr>
+				<tr bgcolor="#ffffff"><td><b>part_fit</b></td><td>%s</td></tr>
+				<tr bgcolor="#eeeeee"><td><b>part_start</b></td><td>%d</td></tr>
+				<tr bgcolor="#ffffff"><td><b>part_size</b></td><td>%d</td></tr>
+				<tr bgcolor="#eeeeee"><td><b>part_next</b></td><td>%d</td></tr>
+				<tr bgcolor="#ffffff"><td><b>part_name</b></td><td>%s</td></tr>
+			`, i+1, escapeLabel(ebr.Mount), escapeLabel(ebr.Fit), ebr.Start, ebr.Size, ebr.Next, escapeLabel(ebr.Name))
 	}
 
-	// Ejecutar el comando Graphviz para generar la imagen
-	cmd := exec.Command("dot", "-Tpng", dotFileName, "-o", outputImage)
-	err = cmd.Run()
-	if err != nil {
-		return fmt.Errorf("error al ejecutar el comando Graphviz: %v", err)
-	}
+	// Cerrar la tabla y el contenido DOT
+	dotContent += "</table>>] }"
+	return dotContent
+}
 
-	fmt.Println("Imagen de la tabla generada:", outputImage)
-	return nil
+// trimNulls convierte un arreglo de bytes de longitud fija en string sin los caracteres nulos finales
+func trimNulls(value []byte) string {
+	return strings.TrimRight(string(value), "\x00")
 }
@@ -2,10 +2,7 @@ package reports
 
 import (
 	structures "archivos_pro1/Structures"
-	"archivos_pro1/utils"
 	"fmt"
-	"os"
-	"os/exec"
 	"time"
 )
 
@@ -70,17 +67,60 @@ import (
 	fmt.Println("SuperBlock.dot file created")
 }*/
 
-func ReportSuperblock(sb *structures.SuperBlock, path string) error {
-	// Crear las carpetas padre si no existen
-	err := utils.CreateParentDirs(path)
+// superblockReport es el modelo del reporte del superbloque
+type superblockReport struct {
+	FilesystemType   int32  `json:"filesystem_type"`
+	InodesCount      int32  `json:"inodes_count"`
+	BlocksCount      int32  `json:"blocks_count"`
+	FreeInodesCount  int32  `json:"free_inodes_count"`
+	FreeBlocksCount  int32  `json:"free_blocks_count"`
+	MountTime        string `json:"mount_time"`
+	UnmountTime      string `json:"unmount_time"`
+	MountCount       int32  `json:"mount_count"`
+	Magic            int32  `json:"magic"`
+	InodeSize        int32  `json:"inode_size"`
+	BlockSize        int32  `json:"block_size"`
+	FirstInode       int32  `json:"first_inode"`
+	FirstBlock       int32  `json:"first_block"`
+	BitmapInodeStart int32  `json:"bitmap_inode_start"`
+	BitmapBlockStart int32  `json:"bitmap_block_start"`
+	InodeStart       int32  `json:"inode_start"`
+	BlockStart       int32  `json:"block_start"`
+}
+
+func ReportSuperblock(sb *structures.SuperBlock, path string, format string) error {
+	model := &superblockReport{
+		FilesystemType:   sb.S_filesystem_type,
+		InodesCount:      sb.S_inodes_count,
+		BlocksCount:      sb.S_blocks_count,
+		FreeInodesCount:  sb.S_free_inodes_count,
+		FreeBlocksCount:  sb.S_free_blocks_count,
+		MountTime:        time.Unix(int64(sb.S_mtime), 0).Format("2006-01-02 15:04:05"),
+		UnmountTime:      time.Unix(int64(sb.S_umtime), 0).Format("2006-01-02 15:04:05"),
+		MountCount:       sb.S_mnt_count,
+		Magic:            sb.S_magic,
+		InodeSize:        sb.S_inode_size,
+		BlockSize:        sb.S_block_size,
+		FirstInode:       sb.S_first_ino,
+		FirstBlock:       sb.S_first_blo,
+		BitmapInodeStart: sb.S_bm_inode_start,
+		BitmapBlockStart: sb.S_bm_block_start,
+		InodeStart:       sb.S_inode_start,
+		BlockStart:       sb.S_block_start,
+	}
+
+	_, err := writeReport(model, path, format)
 	if err != nil {
 		return err
 	}
 
-	dotFileName, outputImage := utils.GetFileNames(path)
+	fmt.Println("SuperBlock report created successfully")
+	return nil
+}
 
+func (model *superblockReport) dot() string {
 	// Definir el contenido DOT con una tabla estilizada
-	dotContent := fmt.Sprintf(`digraph G {
+	return fmt.Sprintf(`digraph G {
 		node [shape=plaintext, fontname="Helvetica, Arial, sans-serif"]
 		tabla [label=<
 			<table border="0" cellborder="1" cellspacing="0" cellpadding="10" bgcolor="#f7f7f7" style="rounded">
@@ -104,28 +144,5 @@ func ReportSuperblock(sb *structures.SuperBlock, path string) error {
 				<tr bgcolor="#eeeeee"><td><b>Block Start</b></td><td>%d</td></tr>
 			</table>
 		> ]}
-	`, sb.S_filesystem_type, sb.S_inodes_count, sb.S_blocks_count, sb.S_free_inodes_count, sb.S_free_blocks_count, time.Unix(int64(sb.S_mtime), 0).Format("2006-01-02 15:04:05"), time.Unix(int64(sb.S_umtime), 0).Format("2006-01-02 15:04:05"), sb.S_mnt_count, sb.S_magic, sb.S_inode_size, sb.S_block_size, sb.S_first_ino, sb.S_first_blo, sb.S_bm_inode_start, sb.S_bm_block_start, sb.S_inode_start, sb.S_block_start)
-
-	// Guardar el contenido DOT en un archivo
-	file, err := os.Create(dotFileName)
-	if err != nil {
-		return fmt.Errorf("error al crear el archivo: %v", err)
-	}
-	defer file.Close()
-
-	_, err = file.WriteString(dotContent)
-	if err != nil {
-		return fmt.Errorf("error al escribir en el archivo: %v", err)
-	}
-
-	//Ejecutar el comando dot para generar la imagen
-	cmd := exec.Command("dot", "-Tpng", dotFileName, "-o", outputImage)
-	err = cmd.Run()
-	if err != nil {
-		return fmt.Errorf("error al ejecutar el comando Graphviz: %v", err)
-	}
-
-	fmt.Println("SuperBlock report created successfully")
-	return nil
-
+	`, model.FilesystemType, model.InodesCount, model.BlocksCount, model.FreeInodesCount, model.FreeBlocksCount, model.MountTime, model.UnmountTime, model.MountCount, model.Magic, model.InodeSize, model.BlockSize, model.FirstInode, model.FirstBlock, model.BitmapInodeStart, model.BitmapBlockStart, model.InodeStart, model.BlockStart)
 }
@@ -0,0 +1,234 @@
+package reports
+
+import (
+	structures "archivos_pro1/Structures"
+	"fmt"
+	"strings"
+)
+
+// treeReport es el modelo del grafo del sistema de archivos
+type treeReport struct {
+	Inodes []treeInode  `json:"inodes"`
+	Blocks []blockModel `json:"blocks"`
+	Edges  []treeEdge   `json:"edges"`
+}
+
+// treeInode es un inodo del grafo; Orphan indica que está usado en el bitmap pero no se alcanza desde la raíz
+type treeInode struct {
+	inodeModel
+	Orphan bool `json:"orphan"`
+}
+
+// treeEdge une un apuntador (Port) del nodo From con el nodo To
+type treeEdge struct {
+	From string `json:"from"`
+	Port string `json:"port"`
+	To   string `json:"to"`
+}
+
+// treeBuilder guarda el estado del recorrido para no repetir inodos ni bloques
+type treeBuilder struct {
+	superblock *structures.SuperBlock
+	diskPath   string
+	model      *treeReport
+	inodes     map[int32]bool
+	blocks     map[int32]bool
+}
+
+// ReportTree genera un grafo de todo el sistema de archivos: inodos, sus bloques y las entradas de carpeta
+func ReportTree(superblock *structures.SuperBlock, diskPath string, path string, format string) error {
+	builder := &treeBuilder{
+		superblock: superblock,
+		diskPath:   diskPath,
+		model:      &treeReport{},
+		inodes:     make(map[int32]bool),
+		blocks:     make(map[int32]bool),
+	}
+
+	// Recorrer el árbol desde la raíz
+	if err := builder.addInode(0, false); err != nil {
+		return err
+	}
+
+	// Los inodos usados en el bitmap que no se alcanzan desde la raíz se marcan como huérfanos
+	used, err := superblock.UsedInodes(diskPath)
+	if err != nil {
+		return fmt.Errorf("error al leer el bitmap de inodos: %v", err)
+	}
+	for _, index := range used {
+		if !builder.inodes[index] {
+			if err := builder.addInode(index, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Guardar el reporte en el formato pedido
+	outputPath, err := writeReport(builder.model, path, format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reporte de árbol generado: %s\n", outputPath)
+	return nil
+}
+
+// addInode agrega el inodo al modelo y recorre sus bloques
+func (builder *treeBuilder) addInode(index int32, orphan bool) error {
+	if builder.inodes[index] {
+		return nil
+	}
+	builder.inodes[index] = true
+
+	inode := &structures.Inode{}
+	if err := inode.Deserialize(builder.diskPath, builder.superblock.InodeOffset(index)); err != nil {
+		return fmt.Errorf("error al deserializar inodo %d: %v", index, err)
+	}
+	builder.model.Inodes = append(builder.model.Inodes, treeInode{inodeModel: newInodeModel(index, inode), Orphan: orphan})
+
+	// Bloques directos y luego los indirectos simple, doble y triple
+	for i, blockIndex := range inode.I_block {
+		if blockIndex == -1 {
+			continue
+		}
+		level := 0
+		if i >= 12 {
+			level = i - 11
+		}
+		if err := builder.addBlock(blockIndex, inode.I_type[0], level); err != nil {
+			return err
+		}
+		builder.addEdge(fmt.Sprintf("inode%d", index), fmt.Sprintf("b%d", i), fmt.Sprintf("block%d", blockIndex))
+	}
+
+	return nil
+}
+
+// addBlock agrega el bloque al modelo; level indica cuántos niveles de apuntadores faltan
+// para llegar a los bloques de datos del inodo de tipo inodeType
+func (builder *treeBuilder) addBlock(index int32, inodeType byte, level int) error {
+	if builder.blocks[index] {
+		return nil
+	}
+	builder.blocks[index] = true
+
+	blockType := "Folder Block"
+	if level > 0 {
+		blockType = "Pointer Block"
+	} else if inodeType == '1' {
+		blockType = "File Block"
+	}
+
+	block, err := readBlockModel(builder.superblock, builder.diskPath, index, blockType)
+	if err != nil {
+		return err
+	}
+	builder.model.Blocks = append(builder.model.Blocks, block)
+
+	from := fmt.Sprintf("block%d", index)
+	switch blockType {
+	case "Pointer Block":
+		for i, pointer := range block.Pointers {
+			if pointer == -1 {
+				continue
+			}
+			if err := builder.addBlock(pointer, inodeType, level-1); err != nil {
+				return err
+			}
+			builder.addEdge(from, fmt.Sprintf("p%d", i), fmt.Sprintf("block%d", pointer))
+		}
+
+	case "Folder Block":
+		// Enlazar cada entrada con su inodo, sin seguir . y ..
+		for i, entry := range block.Entries {
+			if entry.Inode == -1 || entry.Name == "." || entry.Name == ".." {
+				continue
+			}
+			if err := builder.addInode(entry.Inode, false); err != nil {
+				return err
+			}
+			builder.addEdge(from, fmt.Sprintf("e%d", i), fmt.Sprintf("inode%d", entry.Inode))
+		}
+	}
+
+	return nil
+}
+
+func (builder *treeBuilder) addEdge(from string, port string, to string) {
+	builder.model.Edges = append(builder.model.Edges, treeEdge{From: from, Port: port, To: to})
+}
+
+func (model *treeReport) dot() string {
+	var dot strings.Builder
+	dot.WriteString(`digraph TreeReport {
+		rankdir=LR;
+		node [shape=none, fontname="Helvetica, Arial, sans-serif"];
+		graph [splines=true, nodesep=0.5, ranksep=0.6];
+		edge [color=black, arrowhead=normal];
+	`)
+
+	for _, inode := range model.Inodes {
+		// Los inodos huérfanos se marcan en rojo
+		color := "#CCCCCC"
+		if inode.Orphan {
+			color = "#FF9999"
+		}
+
+		fmt.Fprintf(&dot, `inode%d [label=<
+		<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
+			<tr><td colspan="2" bgcolor="%s"><b>INODE %d</b></td></tr>
+			<tr><td>Type</td><td>%s</td></tr>
+			<tr><td>Size</td><td>%d</td></tr>
+			<tr><td>Perm</td><td>%s</td></tr>
+			<tr><td>UID/GID</td><td>%d/%d</td></tr>
+	`, inode.Index, color, inode.Index, escapeLabel(inode.Type), inode.Size, escapeLabel(inode.Perm), inode.UID, inode.GID)
+
+		for i, blockIndex := range inode.Blocks {
+			if blockIndex != -1 {
+				fmt.Fprintf(&dot, `<tr><td>I_block[%d]</td><td port="b%d">%d</td></tr>`, i, i, blockIndex)
+			}
+		}
+		dot.WriteString("</table>>];\n")
+	}
+
+	for _, block := range model.Blocks {
+		switch block.Type {
+		case "Pointer Block":
+			fmt.Fprintf(&dot, `block%d [label=<
+			<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
+				<tr><td colspan="2" bgcolor="#FFE4B5"><b>POINTER BLOCK %d</b></td></tr>
+		`, block.Index, block.Index)
+			for i, pointer := range block.Pointers {
+				if pointer != -1 {
+					fmt.Fprintf(&dot, `<tr><td>Pointer %d</td><td port="p%d">%d</td></tr>`, i, i, pointer)
+				}
+			}
+			dot.WriteString("</table>>];\n")
+
+		case "File Block":
+			fmt.Fprintf(&dot, `block%d [label=<
+			<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
+				<tr><td bgcolor="#FFFACD"><b>FILE BLOCK %d</b></td></tr>
+				<tr><td>%s</td></tr>
+			</table>>];
+		`, block.Index, block.Index, escapeLabel(block.Content))
+
+		case "Folder Block":
+			fmt.Fprintf(&dot, `block%d [label=<
+		<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
+			<tr><td colspan="2" bgcolor="#B0E0E6"><b>FOLDER BLOCK %d</b></td></tr>
+	`, block.Index, block.Index)
+			for i, entry := range block.Entries {
+				fmt.Fprintf(&dot, `<tr><td>%s</td><td port="e%d">%d</td></tr>`, escapeLabel(entry.Name), i, entry.Inode)
+			}
+			dot.WriteString("</table>>];\n")
+		}
+	}
+
+	for _, edge := range model.Edges {
+		fmt.Fprintf(&dot, "%s:%s -> %s;\n", edge.From, edge.Port, edge.To)
+	}
+
+	dot.WriteString("}")
+	return dot.String()
+}
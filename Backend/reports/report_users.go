@@ -0,0 +1,142 @@
+package reports
+
+import (
+	structures "archivos_pro1/Structures"
+	"fmt"
+	"strings"
+)
+
+// usersReport es el modelo del reporte de grupos y usuarios de users.txt
+type usersReport struct {
+	Groups []groupModel `json:"groups"`
+	Users  []userModel  `json:"users"`
+}
+
+type groupModel struct {
+	ID      int32    `json:"id"`
+	Status  string   `json:"status"`
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+type userModel struct {
+	ID     int32  `json:"id"`
+	Status string `json:"status"`
+	Group  string `json:"group"`
+	Name   string `json:"name"`
+}
+
+// ReportUsers genera una tabla con los grupos y usuarios de users.txt y la guarda en la ruta especificada
+func ReportUsers(superblock *structures.SuperBlock, diskPath string, path string, format string) error {
+	usersContent, err := superblock.ReadUsersFile(diskPath)
+	if err != nil {
+		return fmt.Errorf("error al leer users.txt: %v", err)
+	}
+	groups, users := structures.ParseUsersFile(usersContent)
+
+	model := &usersReport{}
+	for _, group := range groups {
+		// Los miembros de un grupo son sus usuarios activos
+		members := []string{}
+		if group.ID != 0 {
+			for _, user := range users {
+				if user.ID != 0 && user.Group == group.Name {
+					members = append(members, user.Name)
+				}
+			}
+		}
+
+		model.Groups = append(model.Groups, groupModel{
+			ID:      group.ID,
+			Status:  recordStatus(group.ID),
+			Name:    group.Name,
+			Members: members,
+		})
+	}
+
+	// La contraseña no se incluye en el reporte
+	for _, user := range users {
+		model.Users = append(model.Users, userModel{
+			ID:     user.ID,
+			Status: recordStatus(user.ID),
+			Group:  user.Group,
+			Name:   user.Name,
+		})
+	}
+
+	// Guardar el reporte en el formato pedido
+	outputPath, err := writeReport(model, path, format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reporte de usuarios generado: %s\n", outputPath)
+	return nil
+}
+
+// recordStatus indica si un registro de users.txt está activo; los eliminados tienen ID 0
+func recordStatus(id int32) string {
+	if id == 0 {
+		return "Eliminado"
+	}
+	return "Activo"
+}
+
+func (model *usersReport) dot() string {
+	var dotContent strings.Builder
+	dotContent.WriteString(`digraph UsersReport {
+	node [shape=none, fontname="Helvetica, Arial, sans-serif"];
+	rankdir=TB;
+	groups [label=<
+		<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
+			<tr><td colspan="4" bgcolor="#CCCCCC"><b>GRUPOS</b></td></tr>
+			<tr>
+				<td bgcolor="#E0E0E0"><b>GID</b></td>
+				<td bgcolor="#E0E0E0"><b>Estado</b></td>
+				<td bgcolor="#E0E0E0"><b>Grupo</b></td>
+				<td bgcolor="#E0E0E0"><b>Miembros</b></td>
+			</tr>
+`)
+
+	for _, group := range model.Groups {
+		escapedMembers := make([]string, len(group.Members))
+		for i, member := range group.Members {
+			escapedMembers[i] = escapeLabel(member)
+		}
+
+		dotContent.WriteString(fmt.Sprintf("\t\t\t<tr><td bgcolor=\"%s\">%d</td><td bgcolor=\"%s\">%s</td><td bgcolor=\"%s\">%s</td><td bgcolor=\"%s\">%s</td></tr>\n",
+			statusColor(group.Status), group.ID, statusColor(group.Status), group.Status,
+			statusColor(group.Status), escapeLabel(group.Name), statusColor(group.Status), strings.Join(escapedMembers, ", ")))
+	}
+
+	dotContent.WriteString(`		</table>>];
+	users [label=<
+		<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
+			<tr><td colspan="4" bgcolor="#CCCCCC"><b>USUARIOS</b></td></tr>
+			<tr>
+				<td bgcolor="#E0E0E0"><b>UID</b></td>
+				<td bgcolor="#E0E0E0"><b>Estado</b></td>
+				<td bgcolor="#E0E0E0"><b>Grupo</b></td>
+				<td bgcolor="#E0E0E0"><b>Usuario</b></td>
+			</tr>
+`)
+
+	for _, user := range model.Users {
+		dotContent.WriteString(fmt.Sprintf("\t\t\t<tr><td bgcolor=\"%s\">%d</td><td bgcolor=\"%s\">%s</td><td bgcolor=\"%s\">%s</td><td bgcolor=\"%s\">%s</td></tr>\n",
+			statusColor(user.Status), user.ID, statusColor(user.Status), user.Status,
+			statusColor(user.Status), escapeLabel(user.Group), statusColor(user.Status), escapeLabel(user.Name)))
+	}
+
+	dotContent.WriteString("\t\t</table>>];\n")
+	dotContent.WriteString("\tgroups -> users [style=invis];\n")
+	dotContent.WriteString("}\n")
+	return dotContent.String()
+}
+
+// statusColor resalta en rojo los registros eliminados
+func statusColor(status string) string {
+	if status == "Eliminado" {
+		return "#FF9999"
+	}
+	return "#FFFFFF"
+}
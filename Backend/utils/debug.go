@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// debugDir es la carpeta de artefactos de depuración; vacía los desactiva
+var debugDir string
+
+// SetDebugDir activa los artefactos de depuración en la carpeta indicada; una cadena vacía los desactiva
+func SetDebugDir(dir string) error {
+	if dir == "" {
+		debugDir = ""
+		return nil
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(absDir, 0755); err != nil {
+		return err
+	}
+
+	debugDir = absDir
+	return nil
+}
+
+// DebugEnabled indica si se configuró una carpeta de artefactos de depuración
+func DebugEnabled() bool {
+	return debugDir != ""
+}
+
+// WriteDebugArtifact guarda un archivo de depuración con el nombre indicado;
+// no hace nada si los artefactos de depuración están desactivados
+func WriteDebugArtifact(name string, content string) error {
+	if !DebugEnabled() {
+		return nil
+	}
+
+	artifactPath := filepath.Join(debugDir, filepath.Base(name))
+	if err := os.WriteFile(artifactPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("error al escribir el artefacto de depuración: %w", err)
+	}
+
+	fmt.Println("Artefacto de depuración creado:", artifactPath)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 )
@@ -84,6 +85,13 @@ func GetParentDirectories(path string) ([]string, string) {
 	return parentDirs, destDir
 }
 
+// IsSubPath indica si child es igual a parent o se encuentra dentro de él
+func IsSubPath(parent string, child string) bool {
+	parent = strings.ToLower(path.Clean("/" + parent))
+	child = strings.ToLower(path.Clean("/" + child))
+	return child == parent || strings.HasPrefix(child, strings.TrimSuffix(parent, "/")+"/")
+}
+
 func First[T any](slice []T) (T, error) {
 	if len(slice) == 0 {
 		var zero T